@@ -7,6 +7,39 @@ CREATE TABLE IF NOT EXISTS users (
     email VARCHAR(255) NOT NULL UNIQUE,
     password_hash VARCHAR(255) NOT NULL,
     name VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member', 'viewer')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Workspaces table (límite de tenencia para tareas y usuarios)
+CREATE TABLE IF NOT EXISTS workspaces (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status_transitions JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Workspace members table
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+-- Projects table (listas de tareas dentro de un workspace)
+CREATE TABLE IF NOT EXISTS projects (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -14,6 +47,9 @@ CREATE TABLE IF NOT EXISTS users (
 -- Tasks table
 CREATE TABLE IF NOT EXISTS tasks (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE SET NULL,
+    parent_task_id UUID REFERENCES tasks(id) ON DELETE SET NULL,
     title VARCHAR(100) NOT NULL,
     description VARCHAR(500),
     status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'in_progress', 'completed', 'cancelled')),
@@ -21,15 +57,273 @@ CREATE TABLE IF NOT EXISTS tasks (
     due_date TIMESTAMP,
     created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     assigned_to UUID REFERENCES users(id) ON DELETE SET NULL,
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    assigned_at TIMESTAMP,
+    deleted_at TIMESTAMP,
+    deleted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('simple', COALESCE(title, '') || ' ' || COALESCE(description, ''))
+    ) STORED,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Task recurrences table (reglas de repetición). La tarea de la regla sirve de
+-- plantilla; cada ocurrencia generada guarda recurrence_id en tasks
+CREATE TABLE IF NOT EXISTS task_recurrences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL UNIQUE REFERENCES tasks(id) ON DELETE CASCADE,
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    repeat_interval INT NOT NULL DEFAULT 1 CHECK (repeat_interval > 0),
+    weekdays SMALLINT[],
+    month_day SMALLINT CHECK (month_day BETWEEN 1 AND 31),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    max_occurrences INT CHECK (max_occurrences > 0),
+    occurrences INT NOT NULL DEFAULT 1,
+    last_task_id UUID REFERENCES tasks(id) ON DELETE SET NULL,
+    last_due_at TIMESTAMP NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Task checklist items table
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    text VARCHAR(200) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Labels table (etiquetas del workspace; con user_id son personales de ese usuario)
+CREATE TABLE IF NOT EXISTS labels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#808080',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Task labels table (relación muchos a muchos entre tareas y etiquetas)
+CREATE TABLE IF NOT EXISTS task_labels (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, label_id)
+);
+
+-- Task assignees table (responsables de la tarea). tasks.assigned_to es el
+-- responsable principal y siempre figura también en esta tabla
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+-- Task watchers table (usuarios que siguen la tarea sin ser responsables)
+CREATE TABLE IF NOT EXISTS task_watchers (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+-- Task attachments table (metadatos; el contenido vive en el BlobStore configurado)
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    storage_key VARCHAR(500) NOT NULL,
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Task dependencies table (blocker_task_id bloquea a blocked_task_id). Los ciclos
+-- se rechazan en la capa de servicio
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    blocker_task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_task_id, blocked_task_id),
+    CHECK (blocker_task_id <> blocked_task_id)
+);
+
+-- Task events table (historial de cambios de tareas). Sin FK a tasks para
+-- conservar el registro de tareas eliminadas
+CREATE TABLE IF NOT EXISTS task_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL,
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    field VARCHAR(50) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Task comments table (comentarios con hilos de respuestas)
+CREATE TABLE IF NOT EXISTS task_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES task_comments(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body VARCHAR(2000) NOT NULL,
+    edited_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Historial de ediciones de comentarios
+CREATE TABLE IF NOT EXISTS task_comment_edits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_id UUID NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    previous_body VARCHAR(2000) NOT NULL,
+    edited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    edited_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Menciones (@email) en comentarios
+CREATE TABLE IF NOT EXISTS task_comment_mentions (
+    comment_id UUID NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (comment_id, user_id)
+);
+
+-- Columnas agregadas después de la versión inicial (bases de datos existentes)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member', 'viewer'));
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id UUID REFERENCES projects(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_task_id UUID REFERENCES tasks(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS started_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector('simple', COALESCE(title, '') || ' ' || COALESCE(description, ''))
+) STORED;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_id UUID REFERENCES task_recurrences(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assigned_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE task_assignees ADD COLUMN IF NOT EXISTS assigned_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE workspaces ADD COLUMN IF NOT EXISTS status_transitions JSONB;
+
+-- Backfill: un workspace personal por usuario sin workspace y tareas huérfanas al workspace de su creador
+INSERT INTO workspaces (name, owner_id)
+SELECT u.name || ' (personal)', u.id FROM users u
+WHERE NOT EXISTS (SELECT 1 FROM workspace_members m WHERE m.user_id = u.id)
+  AND NOT EXISTS (SELECT 1 FROM workspaces w WHERE w.owner_id = u.id);
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT w.id, w.owner_id, 'owner' FROM workspaces w
+WHERE NOT EXISTS (SELECT 1 FROM workspace_members m WHERE m.workspace_id = w.id);
+
+UPDATE tasks t SET workspace_id = (
+    SELECT m.workspace_id FROM workspace_members m
+    WHERE m.user_id = t.created_by
+    ORDER BY m.created_at ASC
+    LIMIT 1
+) WHERE t.workspace_id IS NULL;
+
+-- Tras el backfill toda tarea y todo proyecto pertenece a un workspace
+ALTER TABLE tasks ALTER COLUMN workspace_id SET NOT NULL;
+ALTER TABLE projects ALTER COLUMN workspace_id SET NOT NULL;
+
+-- Backfill: el asignado único de las tareas existentes pasa a ser su primer responsable
+INSERT INTO task_assignees (task_id, user_id)
+SELECT id, assigned_to FROM tasks WHERE assigned_to IS NOT NULL
+ON CONFLICT DO NOTHING;
+
 -- Indexes
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON projects(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON tasks(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id);
 CREATE INDEX IF NOT EXISTS idx_tasks_created_by ON tasks(created_by);
 CREATE INDEX IF NOT EXISTS idx_tasks_assigned_to ON tasks(assigned_to);
 CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
 CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(priority);
 CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date);
 CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks(created_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_search_vector ON tasks USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_task_id ON tasks(parent_task_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_recurrence_occurrence ON tasks(recurrence_id, due_date) WHERE recurrence_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_task_recurrences_active ON task_recurrences(active) WHERE active;
+CREATE INDEX IF NOT EXISTS idx_task_checklist_items_task_id ON task_checklist_items(task_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_scope_name ON labels(workspace_id, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'), LOWER(name));
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels(label_id);
+CREATE INDEX IF NOT EXISTS idx_task_assignees_user_id ON task_assignees(user_id);
+CREATE INDEX IF NOT EXISTS idx_task_watchers_user_id ON task_watchers(user_id);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments(task_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked ON task_dependencies(blocked_task_id);
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id ON task_events(task_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON task_comments(task_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_comments_parent_id ON task_comments(parent_id);
+CREATE INDEX IF NOT EXISTS idx_task_comment_edits_comment_id ON task_comment_edits(comment_id);
+CREATE INDEX IF NOT EXISTS idx_task_comment_mentions_user_id ON task_comment_mentions(user_id);
+
+-- Refresh tokens table (rotación y revocación de sesiones)
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    replaced_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- Respuestas guardadas por Idempotency-Key (POST reintentables)
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INTEGER,
+    content_type VARCHAR(255),
+    body BYTEA,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Eventos en tiempo real de tareas (GET /api/v1/events); se avisan con NOTIFY task_changes
+CREATE TABLE IF NOT EXISTS task_changes (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    actor_id UUID NOT NULL,
+    task JSONB NOT NULL,
+    previous_viewers UUID[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_changes_workspace_id ON task_changes(workspace_id, id);
+CREATE INDEX IF NOT EXISTS idx_task_changes_created_at ON task_changes(created_at);
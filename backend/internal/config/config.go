@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/taskflow/backend/internal/utils/validation"
 )
 
 // Config contiene la configuración de la aplicación
@@ -25,6 +27,33 @@ type Config struct {
 	JWTSecret            string
 	JWTExpirationTime    int64
 	JWTRefreshExpiration int64
+
+	// Workers
+	RecurrenceInterval int64 // segundos entre pasadas del generador de tareas repetitivas
+	TrashRetentionDays int64 // días que una tarea eliminada permanece en la papelera
+	TrashPurgeInterval int64 // segundos entre pasadas del purgador de la papelera
+
+	// Idempotency
+	IdempotencyTTL           int64 // segundos que se guarda la respuesta de un Idempotency-Key
+	IdempotencyPurgeInterval int64 // segundos entre pasadas del purgador de idempotency keys
+
+	// Events
+	EventRetention      int64    // segundos que se guardan los eventos en tiempo real para Last-Event-ID
+	EventPurgeInterval  int64    // segundos entre pasadas del purgador de eventos
+	EventAllowedOrigins []string // orígenes aceptados por el WebSocket de eventos además del propio; "*" acepta cualquiera
+
+	// Tasks
+	BulkMaxTasks int // máximo de tareas por request de POST /tasks/bulk
+
+	// Storage
+	StorageDriver     string // local o s3
+	StoragePath       string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKey       string
+	S3SecretKey       string
+	AttachmentMaxSize int64 // bytes, acotado por validation.MaxRequestBodySize
 }
 
 // Load carga la configuración desde variables de entorno
@@ -33,16 +62,42 @@ func Load() (*Config, error) {
 	godotenv.Load()
 
 	cfg := &Config{
-		DBHost:               getEnv("POSTGRES_HOST", "localhost"),
-		DBPort:               getEnvInt("POSTGRES_PORT", 5432),
-		DBUser:               getEnv("POSTGRES_USER", "postgres"),
-		DBPassword:           getEnv("POSTGRES_PASSWORD", "postgres"),
-		DBName:               getEnv("POSTGRES_DB", "taskflow"),
-		ServerPort:           getEnvInt("SERVER_PORT", 8080),
-		ServerEnv:            getEnv("ENV", "development"),
-		JWTSecret:            getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-		JWTExpirationTime:    getEnvInt64("JWT_EXPIRATION_TIME", 3600),
-		JWTRefreshExpiration: getEnvInt64("JWT_REFRESH_EXPIRATION", 604800),
+		DBHost:                   getEnv("POSTGRES_HOST", "localhost"),
+		DBPort:                   getEnvInt("POSTGRES_PORT", 5432),
+		DBUser:                   getEnv("POSTGRES_USER", "postgres"),
+		DBPassword:               getEnv("POSTGRES_PASSWORD", "postgres"),
+		DBName:                   getEnv("POSTGRES_DB", "taskflow"),
+		ServerPort:               getEnvInt("SERVER_PORT", 8080),
+		ServerEnv:                getEnv("ENV", "development"),
+		JWTSecret:                getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+		JWTExpirationTime:        getEnvInt64("JWT_EXPIRATION_TIME", 3600),
+		JWTRefreshExpiration:     getEnvInt64("JWT_REFRESH_EXPIRATION", 604800),
+		RecurrenceInterval:       getEnvInt64("RECURRENCE_INTERVAL", 60),
+		TrashRetentionDays:       getEnvInt64("TRASH_RETENTION_DAYS", 30),
+		TrashPurgeInterval:       getEnvInt64("TRASH_PURGE_INTERVAL", 3600),
+		EventRetention:           getEnvInt64("EVENT_RETENTION", 86400),
+		EventPurgeInterval:       getEnvInt64("EVENT_PURGE_INTERVAL", 3600),
+		EventAllowedOrigins:      getEnvList("EVENT_ALLOWED_ORIGINS"),
+		BulkMaxTasks:             getEnvInt("BULK_MAX_TASKS", 100),
+		IdempotencyTTL:           getEnvInt64("IDEMPOTENCY_TTL", 86400),
+		IdempotencyPurgeInterval: getEnvInt64("IDEMPOTENCY_PURGE_INTERVAL", 3600),
+		StorageDriver:            getEnv("STORAGE_DRIVER", "local"),
+		StoragePath:              getEnv("STORAGE_PATH", "./uploads"),
+		S3Endpoint:               getEnv("S3_ENDPOINT", "http://localhost:9000"),
+		S3Region:                 getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:                 getEnv("S3_BUCKET", "taskflow"),
+		S3AccessKey:              getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:              getEnv("S3_SECRET_KEY", ""),
+		AttachmentMaxSize:        getEnvInt64("ATTACHMENT_MAX_SIZE", validation.MaxRequestBodySize),
+	}
+
+	if cfg.BulkMaxTasks <= 0 {
+		cfg.BulkMaxTasks = 100
+	}
+
+	// Un adjunto no puede superar el límite de body de ValidationMiddleware
+	if cfg.AttachmentMaxSize <= 0 || cfg.AttachmentMaxSize > validation.MaxRequestBodySize {
+		cfg.AttachmentMaxSize = validation.MaxRequestBodySize
 	}
 
 	return cfg, nil
@@ -87,3 +142,14 @@ func getEnvInt64(key string, defaultVal int64) int64 {
 	}
 	return defaultVal
 }
+
+// getEnvList obtiene una variable de entorno como lista separada por comas
+func getEnvList(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
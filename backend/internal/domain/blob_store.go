@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound indica que la clave no existe en el almacenamiento
+var ErrBlobNotFound = errors.New("archivo no encontrado en el almacenamiento")
+
+// BlobStore define el almacenamiento del contenido de archivos adjuntos
+type BlobStore interface {
+	// Put guarda el contenido bajo la clave, reemplazando el existente
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+
+	// Get abre el contenido guardado bajo la clave. Retorna ErrBlobNotFound si no existe
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete elimina el contenido; eliminar una clave inexistente no es un error
+	Delete(ctx context.Context, key string) error
+}
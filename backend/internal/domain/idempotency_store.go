@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskflow/backend/internal/models"
+)
+
+// IdempotencyStore guarda, por usuario y Idempotency-Key, la huella del request y su
+// respuesta durante un tiempo de vida
+type IdempotencyStore interface {
+	// Reserve registra la clave para el request en curso. Retorna nil si la reservó, o
+	// el registro vigente si la clave ya estaba en uso
+	Reserve(ctx context.Context, userID, key, fingerprint string, ttl time.Duration) (*models.IdempotencyRecord, error)
+
+	// Complete guarda la respuesta del request que reservó la clave
+	Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error
+
+	// Release libera una reserva cuyo request falló, para que se pueda reintentar
+	Release(ctx context.Context, userID, key string) error
+
+	// DeleteExpired elimina las claves vencidas y retorna cuántas eliminó
+	DeleteExpired(ctx context.Context) (int, error)
+}
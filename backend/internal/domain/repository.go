@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/taskflow/backend/internal/models"
 )
@@ -11,6 +12,9 @@ type UserRepository interface {
 	// GetByEmail obtiene un usuario por email
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 
+	// GetCredentialsByEmail obtiene un usuario junto con su hash de contraseña
+	GetCredentialsByEmail(ctx context.Context, email string) (*models.User, string, error)
+
 	// GetByID obtiene un usuario por ID
 	GetByID(ctx context.Context, id string) (*models.User, error)
 
@@ -20,33 +24,323 @@ type UserRepository interface {
 	// Update actualiza un usuario
 	Update(ctx context.Context, user *models.User) error
 
+	// UpdateRole actualiza el rol de un usuario
+	UpdateRole(ctx context.Context, id, role string) error
+
 	// GetAllUsers obtiene todos los usuarios registrados
 	GetAllUsers(ctx context.Context) ([]*models.User, error)
 }
 
-// TaskRepository define los métodos para acceder a datos de tareas
+// RefreshTokenRepository define los métodos para acceder a los refresh tokens emitidos
+type RefreshTokenRepository interface {
+	// Create registra un nuevo refresh token
+	Create(ctx context.Context, token *models.RefreshToken) error
+
+	// GetByID obtiene un refresh token por su ID (jti)
+	GetByID(ctx context.Context, id string) (*models.RefreshToken, error)
+
+	// Rotate revoca el token oldID y registra newToken como su reemplazo.
+	// Retorna false si oldID ya estaba revocado (reutilización de token)
+	Rotate(ctx context.Context, oldID string, newToken *models.RefreshToken) (bool, error)
+
+	// RevokeFamily revoca todos los tokens de una familia (una sesión)
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	// RevokeAllForUser revoca todos los tokens de un usuario (todas las sesiones)
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+// WorkspaceRepository define los métodos para acceder a workspaces y sus miembros
+type WorkspaceRepository interface {
+	// Create crea un workspace y registra al dueño como miembro
+	Create(ctx context.Context, name, description, ownerID string) (string, error)
+
+	// GetByID obtiene un workspace por ID
+	GetByID(ctx context.Context, id string) (*models.Workspace, error)
+
+	// ListForUser obtiene los workspaces a los que pertenece un usuario
+	ListForUser(ctx context.Context, userID string) ([]models.Workspace, error)
+
+	// Update actualiza nombre y descripción de un workspace
+	Update(ctx context.Context, id, name, description string) error
+
+	// Delete elimina un workspace y todas sus tareas
+	Delete(ctx context.Context, id string) error
+
+	// GetMember obtiene la membresía de un usuario en un workspace. Si no es miembro
+	// retorna errors.ErrMemberNotFound
+	GetMember(ctx context.Context, workspaceID, userID string) (*models.WorkspaceMember, error)
+
+	// GetDefaultMembership obtiene la membresía más antigua de un usuario. Si no es
+	// miembro de ningún workspace retorna errors.ErrMemberNotFound
+	GetDefaultMembership(ctx context.Context, userID string) (*models.WorkspaceMember, error)
+
+	// EnsureDefaultMembership obtiene la membresía más antigua del usuario, creando
+	// antes un workspace con ese nombre si no tiene ninguno. Es seguro ante requests
+	// concurrentes: se crea un solo workspace
+	EnsureDefaultMembership(ctx context.Context, userID, name string) (*models.WorkspaceMember, error)
+
+	// ListMembers obtiene los miembros de un workspace
+	ListMembers(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error)
+
+	// AddMember agrega un usuario a un workspace con el rol indicado
+	AddMember(ctx context.Context, workspaceID, userID, role string) error
+
+	// RemoveMember elimina a un usuario de un workspace
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+
+	// GetStatusTransitions obtiene las transiciones de estado personalizadas (nil si usa las por defecto)
+	GetStatusTransitions(ctx context.Context, workspaceID string) (map[string][]string, error)
+
+	// SetStatusTransitions guarda las transiciones personalizadas; nil restablece las por defecto
+	SetStatusTransitions(ctx context.Context, workspaceID string, transitions map[string][]string) error
+}
+
+// ProjectRepository define los métodos para acceder a proyectos de un workspace
+type ProjectRepository interface {
+	// Create crea un nuevo proyecto
+	Create(ctx context.Context, workspaceID, name, description, ownerID string) (string, error)
+
+	// GetByID obtiene un proyecto por ID
+	GetByID(ctx context.Context, workspaceID, id string) (*models.Project, error)
+
+	// List obtiene los proyectos del workspace, opcionalmente incluyendo los archivados
+	List(ctx context.Context, workspaceID string, includeArchived bool) ([]models.Project, error)
+
+	// Update actualiza un proyecto
+	Update(ctx context.Context, workspaceID, id, name, description string, archived bool) error
+
+	// Delete elimina un proyecto; sus tareas quedan sin proyecto
+	Delete(ctx context.Context, workspaceID, id string) error
+}
+
+// CommentRepository define los métodos para acceder a comentarios de tareas
+type CommentRepository interface {
+	// Create crea un comentario y registra sus menciones
+	Create(ctx context.Context, taskID string, parentID *string, authorID, body string, mentionIDs []string) (string, error)
+
+	// GetByID obtiene un comentario por ID dentro del workspace
+	GetByID(ctx context.Context, workspaceID, id string) (*models.Comment, error)
+
+	// ListByTask obtiene los comentarios raíz de una tarea paginados, con sus respuestas
+	ListByTask(ctx context.Context, taskID string, page, pageSize int) ([]models.Comment, int, error)
+
+	// Update guarda el cuerpo anterior en el historial, actualiza el comentario y sus menciones
+	Update(ctx context.Context, id, body, editedBy string, mentionIDs []string) error
+
+	// Delete elimina un comentario y sus respuestas
+	Delete(ctx context.Context, id string) error
+
+	// GetHistory obtiene las versiones anteriores de un comentario
+	GetHistory(ctx context.Context, id string) ([]models.CommentEdit, error)
+}
+
+// ChecklistRepository define los métodos para acceder a la checklist de una tarea
+type ChecklistRepository interface {
+	// List obtiene los ítems de la checklist ordenados por posición
+	List(ctx context.Context, taskID string) ([]models.ChecklistItem, error)
+
+	// GetByID obtiene un ítem de la checklist de la tarea
+	GetByID(ctx context.Context, taskID, id string) (*models.ChecklistItem, error)
+
+	// Create agrega un ítem; sin posición se agrega al final. Como los demás cambios
+	// de la checklist, sube la versión de la tarea y lo registra en su historial
+	Create(ctx context.Context, workspaceID, taskID, text string, position *int, actorID string) (string, error)
+
+	// Update actualiza texto, estado y posición de un ítem
+	Update(ctx context.Context, workspaceID, taskID, id, text string, done bool, position int, actorID string) error
+
+	// Delete elimina un ítem de la checklist
+	Delete(ctx context.Context, workspaceID, taskID, id, actorID string) error
+}
+
+// AttachmentRepository define los métodos para acceder a los metadatos de adjuntos
+type AttachmentRepository interface {
+	// ListByTask obtiene los adjuntos de una tarea, del más antiguo al más reciente
+	ListByTask(ctx context.Context, taskID string) ([]models.Attachment, error)
+
+	// GetByID obtiene un adjunto de la tarea
+	GetByID(ctx context.Context, taskID, id string) (*models.Attachment, error)
+
+	// Create registra un adjunto ya guardado en el BlobStore
+	Create(ctx context.Context, workspaceID string, attachment *models.Attachment) error
+
+	// Delete elimina el registro de un adjunto
+	Delete(ctx context.Context, workspaceID, taskID, id, actorID string) error
+}
+
+// LabelRepository define los métodos para acceder a las etiquetas de tareas.
+// Todas las operaciones están acotadas al workspace indicado
+type LabelRepository interface {
+	// List obtiene las etiquetas del workspace y las personales del usuario
+	List(ctx context.Context, workspaceID, userID string) ([]models.Label, error)
+
+	// GetByID obtiene una etiqueta del workspace
+	GetByID(ctx context.Context, workspaceID, id string) (*models.Label, error)
+
+	// Create crea una etiqueta; con userID la etiqueta es personal
+	Create(ctx context.Context, workspaceID string, userID *string, name, color, createdBy string) (string, error)
+
+	// Update actualiza nombre y color de una etiqueta
+	Update(ctx context.Context, workspaceID, id, name, color string) error
+
+	// Delete elimina una etiqueta y la quita de todas las tareas
+	Delete(ctx context.Context, workspaceID, id string) error
+
+	// Attach aplica la etiqueta a la tarea
+	Attach(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error
+
+	// Detach quita la etiqueta de la tarea
+	Detach(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error
+}
+
+// RecurrenceRepository define los métodos para acceder a las reglas de repetición de tareas
+type RecurrenceRepository interface {
+	// GetByID obtiene una regla de repetición del workspace
+	GetByID(ctx context.Context, workspaceID, id string) (*models.TaskRecurrence, error)
+
+	// Create crea la regla y asocia su tarea como primera ocurrencia
+	Create(ctx context.Context, rule *models.TaskRecurrence, actorID string) (string, error)
+
+	// Update actualiza frecuencia, intervalo, días, fin y estado de la regla
+	Update(ctx context.Context, rule *models.TaskRecurrence) error
+
+	// Delete elimina la regla; las ocurrencias ya generadas se conservan
+	Delete(ctx context.Context, workspaceID, id, actorID string) error
+
+	// ListDue obtiene reglas activas de todos los workspaces cuya última ocurrencia
+	// ya venció, se cerró o se eliminó
+	ListDue(ctx context.Context, now time.Time, limit int) ([]models.TaskRecurrence, error)
+
+	// CreateOccurrence crea la ocurrencia que vence en dueDate si la regla no avanzó
+	// desde que se leyó. Retorna "" si la ocurrencia ya existía u otra instancia ya la generó
+	CreateOccurrence(ctx context.Context, rule *models.TaskRecurrence, dueDate time.Time) (string, error)
+
+	// Deactivate marca la regla como terminada
+	Deactivate(ctx context.Context, id string) error
+}
+
+// DependencyRepository define los métodos para acceder a las dependencias entre
+// tareas. Todas las operaciones están acotadas al workspace indicado
+type DependencyRepository interface {
+	// LockGraph bloquea el grafo de dependencias del workspace hasta el fin de la
+	// transacción de Transactor.WithinTx, para verificar y agregar una arista sin que
+	// otra escritura concurrente cierre un ciclo entre medio
+	LockGraph(ctx context.Context, workspaceID string) error
+
+	// Add registra que blockerID bloquea a blockedID
+	Add(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error
+
+	// Remove elimina la dependencia entre dos tareas
+	Remove(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error
+
+	// ListEdges obtiene todas las dependencias del workspace
+	ListEdges(ctx context.Context, workspaceID string) ([]models.TaskDependencyEdge, error)
+
+	// CountOpenBlockers cuenta los bloqueadores directos que no están completados ni cancelados
+	CountOpenBlockers(ctx context.Context, workspaceID, taskID string) (int, error)
+}
+
+// TaskRepository define los métodos para acceder a datos de tareas.
+// Todas las operaciones están acotadas al workspace indicado
 type TaskRepository interface {
 	// GetAll obtiene todas las tareas con filtros y paginación
-	GetAll(ctx context.Context, userID, status string, page, pageSize int) ([]models.Task, int, error)
+	GetAll(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, page, pageSize int) ([]models.Task, int, error)
+
+	// GetAfter obtiene hasta limit tareas posteriores al cursor (nil para empezar) y el cursor
+	// de la siguiente página (nil si no hay más)
+	GetAfter(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, after *models.TaskCursor, limit int) ([]models.Task, *models.TaskCursor, error)
+
+	// Count cuenta las tareas que cumplen el filtro
+	Count(ctx context.Context, workspaceID string, filter models.TaskFilter) (int, error)
 
-	// GetByID obtiene una tarea por ID
-	GetByID(ctx context.Context, id string) (*models.Task, error)
+	// GetByID obtiene una tarea por ID. De las etiquetas personales solo carga las de
+	// viewerID (vacío para ninguna)
+	GetByID(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error)
 
 	// Create crea una nueva tarea
-	Create(ctx context.Context, title, description, priority string, dueDate interface{}, createdBy string) (string, error)
+	Create(ctx context.Context, workspaceID, title, description, priority string, dueDate interface{}, projectID, parentID *string, createdBy string) (string, error)
 
-	// Update actualiza una tarea
-	Update(ctx context.Context, id, title, description, priority string, dueDate interface{}) error
+	// Update actualiza una tarea. Con version distinta de 0 retorna
+	// errors.ErrTaskVersionConflict si la tarea ya no está en esa versión; lo mismo
+	// aplica a Delete y UpdateStatus
+	Update(ctx context.Context, workspaceID, id, title, description, priority string, dueDate interface{}, version int, actorID string) error
 
-	// Delete elimina una tarea
-	Delete(ctx context.Context, id string) error
+	// Patch actualiza solo los campos presentes en patch; nil limpia la columna
+	Patch(ctx context.Context, workspaceID, id string, patch models.TaskPatch, version int, actorID string) error
+
+	// Delete mueve una tarea a la papelera
+	Delete(ctx context.Context, workspaceID, id string, version int, actorID string) error
+
+	// GetTrashedByID obtiene una tarea de la papelera; viewerID como en GetByID
+	GetTrashedByID(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error)
+
+	// Restore saca una tarea de la papelera
+	Restore(ctx context.Context, workspaceID, id, actorID string) error
+
+	// Purge elimina definitivamente hasta limit tareas en la papelera desde antes de
+	// cutoff; retorna cuántas eliminó y las claves de sus adjuntos en el BlobStore
+	Purge(ctx context.Context, cutoff time.Time, limit int) (int, []string, error)
+
+	// UpdateStatus cambia el estado de una tarea que sigue en fromStatus; si otro
+	// request lo cambió antes retorna ErrTaskStatusConflict
+	UpdateStatus(ctx context.Context, workspaceID, id, fromStatus, status string, version int, actorID string) error
+
+	// AssignTask reemplaza al responsable principal; vacío quita todos los responsables
+	AssignTask(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+
+	// AddAssignee agrega un responsable; si la tarea no tenía, pasa a ser el principal
+	AddAssignee(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+
+	// RemoveAssignee quita un responsable; si era el principal lo reemplaza el más antiguo restante
+	RemoveAssignee(ctx context.Context, workspaceID, taskID, userID, actorID string) error
 
-	// UpdateStatus actualiza el estado de una tarea
-	UpdateStatus(ctx context.Context, id, status string) error
+	// AddWatcher agrega un seguidor a la tarea (sin efecto si ya la seguía)
+	AddWatcher(ctx context.Context, workspaceID, taskID, userID, actorID string) error
 
-	// AssignTask asigna una tarea a un usuario
-	AssignTask(ctx context.Context, taskID, userID string) error
+	// RemoveWatcher quita un seguidor de la tarea
+	RemoveWatcher(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+
+	// GetStats obtiene estadísticas de las tareas que cumplen el filtro
+	GetStats(ctx context.Context, workspaceID string, filter models.TaskFilter) (*models.TaskStats, error)
+
+	// SetParent mueve la tarea bajo otra tarea; nil la convierte en tarea raíz.
+	// Vuelve a verificar la jerarquía con el workspace bloqueado y retorna
+	// errors.ErrTaskParentCycle si el cambio cerraría un ciclo
+	SetParent(ctx context.Context, workspaceID, id string, parentID *string, actorID string) error
+
+	// HasAncestor indica si ancestorID aparece en la cadena de tareas padre de taskID
+	HasAncestor(ctx context.Context, workspaceID, taskID, ancestorID string) (bool, error)
+
+	// CountOpenSubtasks cuenta las subtareas directas que no están completadas ni canceladas
+	CountOpenSubtasks(ctx context.Context, workspaceID, id string) (int, error)
+
+	// GetEvents obtiene el historial de cambios de una tarea paginado
+	GetEvents(ctx context.Context, workspaceID, taskID string, page, pageSize int) ([]models.TaskEvent, int, error)
+}
+
+// TaskChangeRepository define los métodos para acceder a los eventos en tiempo real de tareas
+type TaskChangeRepository interface {
+	// Create registra el evento, le asigna ID y fecha, y avisa a todas las instancias
+	// (el aviso se envía al confirmar la transacción)
+	Create(ctx context.Context, change *models.TaskChange) error
+
+	// GetByID obtiene un evento
+	GetByID(ctx context.Context, id int64) (*models.TaskChange, error)
+
+	// ListAfter obtiene hasta limit eventos posteriores a afterID en orden de ID: los de
+	// ID mayor y, como los IDs no se confirman en orden, también los de ID menor creados
+	// hasta window antes que afterID. No incluye afterID; workspaceID vacío no filtra
+	// por workspace
+	ListAfter(ctx context.Context, workspaceID string, afterID int64, window time.Duration, limit int) ([]models.TaskChange, error)
+
+	// DeleteBefore elimina los eventos creados antes de la fecha
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
 
-	// GetStats obtiene estadísticas de tareas de un usuario
-	GetStats(ctx context.Context, userID string) (*models.TaskStats, error)
+// Transactor agrupa operaciones de varios repositorios en una sola transacción
+type Transactor interface {
+	// WithinTx ejecuta fn en una transacción. Las escrituras de tareas que reciben el ctx
+	// de fn se suman a ella; si fn retorna error se revierten todas
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
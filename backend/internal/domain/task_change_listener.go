@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// TaskChangeListener recibe los avisos de eventos de tareas publicados por cualquier instancia
+type TaskChangeListener interface {
+	// Listen bloquea hasta que se cancele ctx y llama a notify con el ID de cada evento
+	// publicado. notify(0) indica que pudieron perderse avisos (por ejemplo, al reconectar)
+	Listen(ctx context.Context, notify func(id int64)) error
+}
@@ -34,6 +34,91 @@ var (
 		Message: "Tarea no encontrada",
 	}
 
+	ErrWorkspaceNotFound = &AppError{
+		Code:    404,
+		Message: "Workspace no encontrado",
+	}
+
+	ErrMemberNotFound = &AppError{
+		Code:    404,
+		Message: "El usuario no es miembro del workspace",
+	}
+
+	ErrAlreadyMember = &AppError{
+		Code:    409,
+		Message: "El usuario ya es miembro del workspace",
+	}
+
+	ErrProjectNotFound = &AppError{
+		Code:    404,
+		Message: "Proyecto no encontrado",
+	}
+
+	ErrProjectArchived = &AppError{
+		Code:    422,
+		Message: "El proyecto está archivado",
+	}
+
+	ErrChecklistItemNotFound = &AppError{
+		Code:    404,
+		Message: "Ítem de checklist no encontrado",
+	}
+
+	ErrAttachmentNotFound = &AppError{
+		Code:    404,
+		Message: "Adjunto no encontrado",
+	}
+
+	ErrAssigneeNotFound = &AppError{
+		Code:    404,
+		Message: "El usuario no es responsable de la tarea",
+	}
+
+	ErrWatcherNotFound = &AppError{
+		Code:    404,
+		Message: "El usuario no sigue la tarea",
+	}
+
+	ErrLabelNotFound = &AppError{
+		Code:    404,
+		Message: "Etiqueta no encontrada",
+	}
+
+	ErrLabelExists = &AppError{
+		Code:    409,
+		Message: "Ya existe una etiqueta con ese nombre",
+	}
+
+	ErrRecurrenceNotFound = &AppError{
+		Code:    404,
+		Message: "La tarea no tiene regla de repetición",
+	}
+
+	ErrDependencyNotFound = &AppError{
+		Code:    404,
+		Message: "Dependencia no encontrada",
+	}
+
+	ErrTaskVersionConflict = &AppError{
+		Code:    412,
+		Message: "La tarea fue modificada por otro usuario",
+	}
+
+	ErrTaskStatusConflict = &AppError{
+		Code:    409,
+		Message: "El estado de la tarea cambió mientras se actualizaba; vuelva a intentarlo",
+	}
+
+	ErrTaskParentCycle = &AppError{
+		Code:    422,
+		Message: "La tarea padre es una subtarea de esta tarea; se crearía un ciclo",
+	}
+
+	ErrCommentNotFound = &AppError{
+		Code:    404,
+		Message: "Comentario no encontrado",
+	}
+
 	ErrEmailAlreadyExists = &AppError{
 		Code:    409,
 		Message: "El email ya está registrado",
@@ -44,6 +129,11 @@ var (
 		Message: "No autorizado",
 	}
 
+	ErrForbidden = &AppError{
+		Code:    403,
+		Message: "No tienes permiso para realizar esta acción",
+	}
+
 	ErrInvalidToken = &AppError{
 		Code:    401,
 		Message: "Token inválido o expirado",
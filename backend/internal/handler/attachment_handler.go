@@ -0,0 +1,199 @@
+package handler
+
+import (
+	stderrors "errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/service"
+	"github.com/taskflow/backend/internal/utils/validation"
+)
+
+// AttachmentHandler maneja los endpoints de archivos adjuntos de tareas
+type AttachmentHandler struct {
+	attachmentService *service.AttachmentService
+	responseWriter    response.ResponseWriter
+}
+
+// NewAttachmentHandler crea una nueva instancia de AttachmentHandler
+func NewAttachmentHandler(attachmentService *service.AttachmentService, rw response.ResponseWriter) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		responseWriter:    rw,
+	}
+}
+
+// ListAttachments godoc
+// @Summary Listar adjuntos de una tarea
+// @Description Obtiene los metadatos de los archivos adjuntos, del más antiguo al más reciente
+// @Tags Attachments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse{data=[]models.Attachment}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	attachments, err := h.attachmentService.ListAttachments(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Adjuntos obtenidos exitosamente", attachments)
+}
+
+// UploadAttachment godoc
+// @Summary Adjuntar archivo a una tarea
+// @Description Sube un archivo (campo multipart "file") de hasta 10MB. El tipo MIME se detecta a partir del contenido y se registra su checksum SHA-256
+// @Tags Attachments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param file formData file true "Archivo a adjuntar"
+// @Success 201 {object} models.APIResponse{data=models.Attachment}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 413 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	// ValidationMiddleware solo revisa Content-Length; esto cubre los bodies chunked
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, validation.MaxRequestBodySize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			h.responseWriter.Error(c, http.StatusRequestEntityTooLarge, "El archivo excede el tamaño máximo permitido")
+			return
+		}
+		h.responseWriter.ValidationError(c, "Debe enviar un archivo en el campo file")
+		return
+	}
+
+	if fileHeader.Size > h.attachmentService.MaxSize() {
+		h.responseWriter.Error(c, http.StatusRequestEntityTooLarge, "El archivo excede el tamaño máximo permitido")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.responseWriter.ValidationError(c, "No se pudo leer el archivo")
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), c.Param("id"), fileHeader.Filename, file, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Archivo adjuntado exitosamente", attachment)
+}
+
+// DownloadAttachment godoc
+// @Summary Descargar adjunto
+// @Description Descarga el contenido de un archivo adjunto
+// @Tags Attachments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce octet-stream
+// @Param id path string true "ID de la tarea"
+// @Param attachment_id path string true "ID del adjunto"
+// @Success 200 {file} file
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	attachment, content, err := h.attachmentService.Download(c.Request.Context(), c.Param("id"), c.Param("attachment_id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer content.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+		"X-Checksum-SHA256":      attachment.Checksum,
+		"Cache-Control":          "private, no-cache",
+	})
+}
+
+// DeleteAttachment godoc
+// @Summary Eliminar adjunto
+// @Description Elimina un archivo adjunto de la tarea
+// @Tags Attachments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param attachment_id path string true "ID del adjunto"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.attachmentService.DeleteAttachment(c.Request.Context(), c.Param("id"), c.Param("attachment_id"), actor); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Adjunto eliminado exitosamente", nil)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *AttachmentHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
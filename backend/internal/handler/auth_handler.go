@@ -108,6 +108,64 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	h.responseWriter.Success(c, http.StatusOK, "Token refrescado exitosamente", resp)
 }
 
+// Logout godoc
+// @Summary Cerrar sesión actual
+// @Description Revoca el refresh token indicado y todos los de su misma sesión
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body models.LogoutRequest true "Refresh token de la sesión"
+// @Success 200 {object} models.APIResponse
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	var req models.LogoutRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), userID.(string), req.RefreshToken); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Sesión cerrada exitosamente", nil)
+}
+
+// LogoutAll godoc
+// @Summary Cerrar todas las sesiones
+// @Description Revoca todos los refresh tokens del usuario autenticado en todos sus dispositivos
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.authService.LogoutAll(c.Request.Context(), userID.(string)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Todas las sesiones fueron cerradas", nil)
+}
+
 // GetProfile godoc
 // @Summary Obtener perfil del usuario actual
 // @Description Obtiene la información del usuario autenticado
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+)
+
+// ChecklistHandler maneja los endpoints de la checklist de una tarea
+type ChecklistHandler struct {
+	checklistService *service.ChecklistService
+	responseWriter   response.ResponseWriter
+}
+
+// NewChecklistHandler crea una nueva instancia de ChecklistHandler
+func NewChecklistHandler(checklistService *service.ChecklistService, rw response.ResponseWriter) *ChecklistHandler {
+	return &ChecklistHandler{
+		checklistService: checklistService,
+		responseWriter:   rw,
+	}
+}
+
+// ListItems godoc
+// @Summary Listar checklist de una tarea
+// @Description Obtiene los ítems de la checklist ordenados por posición
+// @Tags Checklist
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse{data=[]models.ChecklistItem}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/checklist [get]
+func (h *ChecklistHandler) ListItems(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	items, err := h.checklistService.ListItems(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Checklist obtenida exitosamente", items)
+}
+
+// CreateItem godoc
+// @Summary Agregar ítem a la checklist
+// @Description Agrega un ítem a la checklist de la tarea; sin posición se agrega al final
+// @Tags Checklist
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.CreateChecklistItemRequest true "Datos del ítem"
+// @Success 201 {object} models.APIResponse{data=models.ChecklistItem}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/checklist [post]
+func (h *ChecklistHandler) CreateItem(c *gin.Context) {
+	var req models.CreateChecklistItemRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	item, err := h.checklistService.CreateItem(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Ítem agregado exitosamente", item)
+}
+
+// UpdateItem godoc
+// @Summary Actualizar ítem de la checklist
+// @Description Edita el texto, marca como hecho o cambia la posición de un ítem
+// @Tags Checklist
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param item_id path string true "ID del ítem"
+// @Param request body models.UpdateChecklistItemRequest true "Campos a actualizar"
+// @Success 200 {object} models.APIResponse{data=models.ChecklistItem}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/checklist/{item_id} [patch]
+func (h *ChecklistHandler) UpdateItem(c *gin.Context) {
+	var req models.UpdateChecklistItemRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	item, err := h.checklistService.UpdateItem(c.Request.Context(), c.Param("id"), c.Param("item_id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Ítem actualizado exitosamente", item)
+}
+
+// DeleteItem godoc
+// @Summary Eliminar ítem de la checklist
+// @Description Elimina un ítem de la checklist de la tarea
+// @Tags Checklist
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param item_id path string true "ID del ítem"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/checklist/{item_id} [delete]
+func (h *ChecklistHandler) DeleteItem(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.checklistService.DeleteItem(c.Request.Context(), c.Param("id"), c.Param("item_id"), actor); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Ítem eliminado exitosamente", nil)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *ChecklistHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
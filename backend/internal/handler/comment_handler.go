@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+)
+
+// CommentHandler maneja los endpoints de comentarios de tareas
+type CommentHandler struct {
+	commentService *service.CommentService
+	responseWriter response.ResponseWriter
+}
+
+// NewCommentHandler crea una nueva instancia de CommentHandler
+func NewCommentHandler(commentService *service.CommentService, rw response.ResponseWriter) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		responseWriter: rw,
+	}
+}
+
+// ListComments godoc
+// @Summary Listar comentarios de una tarea
+// @Description Obtiene los comentarios raíz de la tarea con paginación; cada uno incluye sus respuestas
+// @Tags Comments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param page query int false "Número de página" default(1)
+// @Param page_size query int false "Tamaño de página" default(20)
+// @Success 200 {object} models.APIResponse{data=models.CommentsListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	// page y page_size ya fueron normalizados por ValidationMiddleware
+	resp, err := h.commentService.ListComments(c.Request.Context(), c.Param("id"), c.GetInt("page"), c.GetInt("page_size"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Comentarios obtenidos exitosamente", resp)
+}
+
+// CreateComment godoc
+// @Summary Comentar una tarea
+// @Description Agrega un comentario o una respuesta (parent_id) a una tarea visible para el usuario. Las menciones @email a miembros del workspace se registran
+// @Tags Comments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.CreateCommentRequest true "Contenido del comentario"
+// @Success 201 {object} models.APIResponse{data=models.Comment}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	var req models.CreateCommentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Comentario creado exitosamente", comment)
+}
+
+// UpdateComment godoc
+// @Summary Editar comentario
+// @Description Edita un comentario propio; la versión anterior se conserva en el historial
+// @Tags Comments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Param request body models.UpdateCommentRequest true "Nuevo contenido"
+// @Success 200 {object} models.APIResponse{data=models.Comment}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/comments/{id} [patch]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	var req models.UpdateCommentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Comentario actualizado exitosamente", comment)
+}
+
+// DeleteComment godoc
+// @Summary Eliminar comentario
+// @Description Elimina un comentario y sus respuestas (autor o administradores)
+// @Tags Comments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.commentService.DeleteComment(c.Request.Context(), c.Param("id"), actor); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Comentario eliminado exitosamente", nil)
+}
+
+// GetCommentHistory godoc
+// @Summary Historial de ediciones de un comentario
+// @Description Obtiene las versiones anteriores de un comentario, de la más reciente a la más antigua
+// @Tags Comments
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID del comentario"
+// @Success 200 {object} models.APIResponse{data=[]models.CommentEdit}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/comments/{id}/history [get]
+func (h *CommentHandler) GetCommentHistory(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	edits, err := h.commentService.GetCommentHistory(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Historial obtenido exitosamente", edits)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *CommentHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+)
+
+// DependencyHandler maneja los endpoints de dependencias entre tareas
+type DependencyHandler struct {
+	dependencyService *service.DependencyService
+	responseWriter    response.ResponseWriter
+}
+
+// NewDependencyHandler crea una nueva instancia de DependencyHandler
+func NewDependencyHandler(dependencyService *service.DependencyService, rw response.ResponseWriter) *DependencyHandler {
+	return &DependencyHandler{
+		dependencyService: dependencyService,
+		responseWriter:    rw,
+	}
+}
+
+// GetDependencies godoc
+// @Summary Obtener dependencias de una tarea
+// @Description Obtiene las tareas por las que espera (upstream) y las que esperan por ella (downstream), directas e indirectas
+// @Tags Dependencies
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse{data=models.TaskDependencyGraph}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/dependencies [get]
+func (h *DependencyHandler) GetDependencies(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	graph, err := h.dependencyService.GetDependencies(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Dependencias obtenidas exitosamente", graph)
+}
+
+// AddDependency godoc
+// @Summary Agregar dependencia
+// @Description Registra que task_id bloquea a la tarea (type=blocked_by, por defecto) o que la tarea bloquea a task_id (type=blocks). Las dependencias que crearían un ciclo se rechazan con 422
+// @Tags Dependencies
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.AddDependencyRequest true "Tarea relacionada"
+// @Success 201 {object} models.APIResponse{data=models.TaskDependencyGraph}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/dependencies [post]
+func (h *DependencyHandler) AddDependency(c *gin.Context) {
+	var req models.AddDependencyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	graph, err := h.dependencyService.AddDependency(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Dependencia creada exitosamente", graph)
+}
+
+// RemoveDependency godoc
+// @Summary Eliminar dependencia
+// @Description Elimina la dependencia entre la tarea y task_id, en cualquier dirección
+// @Tags Dependencies
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param task_id path string true "ID de la tarea relacionada"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/dependencies/{task_id} [delete]
+func (h *DependencyHandler) RemoveDependency(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.dependencyService.RemoveDependency(c.Request.Context(), c.Param("id"), c.Param("task_id"), actor); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Dependencia eliminada exitosamente", nil)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *DependencyHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// eventHeartbeatInterval cada cuánto se envía un heartbeat si no hubo eventos, para
+	// que proxies y clientes no cierren la conexión por inactividad
+	eventHeartbeatInterval = 15 * time.Second
+	// eventWriteTimeout tiempo máximo para enviar un mensaje por WebSocket
+	eventWriteTimeout = 10 * time.Second
+)
+
+// EventHandler maneja los streams de eventos en tiempo real de tareas
+type EventHandler struct {
+	eventHub       *service.EventHub
+	allowedOrigins []string
+	responseWriter response.ResponseWriter
+}
+
+// NewEventHandler crea una nueva instancia de EventHandler. allowedOrigins son los
+// orígenes que el WebSocket acepta además del propio ("*" acepta cualquiera)
+func NewEventHandler(eventHub *service.EventHub, allowedOrigins []string, rw response.ResponseWriter) *EventHandler {
+	return &EventHandler{
+		eventHub:       eventHub,
+		allowedOrigins: allowedOrigins,
+		responseWriter: rw,
+	}
+}
+
+// eventHeartbeat mensaje de heartbeat del WebSocket
+type eventHeartbeat struct {
+	Type string `json:"type"`
+}
+
+// StreamEvents godoc
+// @Summary Stream de eventos de tareas (SSE)
+// @Description Server-Sent Events con los cambios de las tareas del workspace activo que el usuario puede ver (task.created, task.updated, task.status_changed, task.assigned, task.deleted, task.restored). Quien deja de ver una tarea por un cambio recibe task.revoked, con solo id, type y task_id. Cada evento lleva su id; al reconectar, Last-Event-ID (header o query last_event_id) reenvía los eventos perdidos, y puede repetir alguno ya recibido: el cliente descarta los id repetidos. Sin eventos se envía un comentario de heartbeat cada 15 segundos; en cada uno se vuelven a verificar el rol y la membresía, y si el usuario ya no es miembro del workspace el stream se cierra
+// @Tags Events
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Param Last-Event-ID header string false "ID del último evento recibido"
+// @Param last_event_id query string false "ID del último evento recibido (alternativa al header)"
+// @Produce text/event-stream
+// @Success 200 {object} models.TaskChange
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 410 {object} models.APIResponse
+// @Router /api/v1/events [get]
+func (h *EventHandler) StreamEvents(c *gin.Context) {
+	sub, backlog, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer h.eventHub.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	send := func(change models.TaskChange) {
+		c.Render(-1, sse.Event{
+			Id:    strconv.FormatInt(change.ID, 10),
+			Event: change.Type,
+			Data:  change,
+		})
+		c.Writer.Flush()
+	}
+
+	for _, change := range backlog {
+		send(change)
+	}
+	sent := backlogIDs(backlog)
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case change, open := <-sub.Events():
+			if !open {
+				return
+			}
+			// Los eventos que llegaron mientras se leían los pendientes ya se enviaron
+			if sent.repeated(change.ID) {
+				continue
+			}
+			send(change)
+		case <-heartbeat.C:
+			// El rol y la membresía se fijaron al conectar: si cambiaron, se aplican
+			// desde aquí, y si ya no es miembro el stream termina
+			if !h.eventHub.Revalidate(c.Request.Context(), sub) {
+				return
+			}
+			c.Writer.WriteString(": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+// StreamEventsWebSocket godoc
+// @Summary Stream de eventos de tareas (WebSocket)
+// @Description Variante WebSocket de GET /api/v1/events: cada mensaje es un evento en JSON y sin eventos se envía {"type":"heartbeat"} cada 15 segundos. Last-Event-ID, task.revoked y la verificación de la membresía en cada heartbeat funcionan igual que en SSE. Si el request trae Origin (navegadores), debe ser el del servidor o uno de EVENT_ALLOWED_ORIGINS
+// @Tags Events
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Param Last-Event-ID header string false "ID del último evento recibido"
+// @Param last_event_id query string false "ID del último evento recibido (alternativa al header)"
+// @Success 101 {object} models.TaskChange
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 410 {object} models.APIResponse
+// @Router /api/v1/events/ws [get]
+func (h *EventHandler) StreamEventsWebSocket(c *gin.Context) {
+	if !h.originAllowed(c.Request) {
+		h.responseWriter.Forbidden(c, "Origin no permitido")
+		return
+	}
+
+	sub, backlog, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer h.eventHub.Unsubscribe(sub)
+
+	server := websocket.Server{Handshake: acceptOrigin, Handler: func(conn *websocket.Conn) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		// El cliente no envía mensajes: la lectura solo detecta el cierre de la conexión
+		go func() {
+			defer cancel()
+			var discard string
+			for websocket.Message.Receive(conn, &discard) == nil {
+			}
+		}()
+
+		send := func(message interface{}) bool {
+			conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+			return websocket.JSON.Send(conn, message) == nil
+		}
+
+		for _, change := range backlog {
+			if !send(change) {
+				return
+			}
+		}
+		sent := backlogIDs(backlog)
+
+		heartbeat := time.NewTicker(eventHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, open := <-sub.Events():
+				if !open {
+					return
+				}
+				if sent.repeated(change.ID) {
+					continue
+				}
+				if !send(change) {
+					return
+				}
+			case <-heartbeat.C:
+				if !h.eventHub.Revalidate(ctx, sub) || !send(eventHeartbeat{Type: "heartbeat"}) {
+					return
+				}
+			}
+		}
+	}}
+
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// originAllowed verifica el Origin del WebSocket, que a diferencia de fetch no está
+// sujeto a CORS. Sin Origin (clientes que no son navegadores) se acepta: la
+// autenticación va en el header Authorization, que otro sitio no puede agregar
+func (h *EventHandler) originAllowed(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(parsed.Host, req.Host) {
+		return true
+	}
+
+	for _, allowed := range h.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptOrigin handshake del WebSocket: el Origin ya se verificó en originAllowed,
+// aquí solo se registra y se aceptan los requests sin Origin
+func acceptOrigin(config *websocket.Config, req *http.Request) error {
+	var err error
+	config.Origin, err = websocket.Origin(config, req)
+	return err
+}
+
+// sentIDs IDs de los eventos pendientes ya enviados. No alcanza con comparar contra el
+// último: un evento de ID menor puede confirmarse y llegar por el canal después
+type sentIDs map[int64]struct{}
+
+// backlogIDs arma el conjunto de IDs de los eventos pendientes
+func backlogIDs(backlog []models.TaskChange) sentIDs {
+	ids := make(sentIDs, len(backlog))
+	for _, change := range backlog {
+		ids[change.ID] = struct{}{}
+	}
+	return ids
+}
+
+// repeated indica si el evento ya se envió. Cada uno llega como mucho una vez por el
+// canal, así que se olvida al encontrarlo
+func (s sentIDs) repeated(id int64) bool {
+	if _, ok := s[id]; !ok {
+		return false
+	}
+	delete(s, id)
+	return true
+}
+
+// subscribe lee Last-Event-ID y suscribe al actor al workspace activo. Si falla
+// responde el error y retorna false
+func (h *EventHandler) subscribe(c *gin.Context) (*service.Subscription, []models.TaskChange, bool) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return nil, nil, false
+	}
+
+	lastEventID, ok := lastEventIDFromRequest(c)
+	if !ok {
+		h.responseWriter.ValidationError(c, "Last-Event-ID inválido")
+		return nil, nil, false
+	}
+
+	sub, backlog, err := h.eventHub.Subscribe(c.Request.Context(), actor, lastEventID)
+	if err != nil {
+		h.handleError(c, err)
+		return nil, nil, false
+	}
+
+	return sub, backlog, true
+}
+
+// lastEventIDFromRequest lee el ID del último evento recibido del header Last-Event-ID
+// (lo envía EventSource al reconectar) o del query last_event_id. Sin ninguno retorna 0
+func lastEventIDFromRequest(c *gin.Context) (int64, bool) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maneja los errores de la aplicación
+func (h *EventHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
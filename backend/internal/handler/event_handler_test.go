@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+)
+
+func TestStreamEventsWebSocketChecksOrigin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		want    bool
+	}{
+		{name: "sin Origin", origin: "", want: true},
+		{name: "mismo origen", origin: "http://api.taskflow.local", want: true},
+		{name: "otro origen", origin: "https://evil.example", want: false},
+		{name: "origen permitido", allowed: []string{"https://app.taskflow.local"}, origin: "https://app.taskflow.local", want: true},
+		{name: "otro esquema del origen permitido", allowed: []string{"https://app.taskflow.local"}, origin: "http://app.taskflow.local", want: false},
+		{name: "comodín", allowed: []string{"*"}, origin: "https://evil.example", want: true},
+		{name: "Origin inválido", origin: "://", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewEventHandler(nil, tt.allowed, response.NewResponseWriter())
+			req := httptest.NewRequest(http.MethodGet, "http://api.taskflow.local/api/v1/events/ws", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			if got := h.originAllowed(req); got != tt.want {
+				t.Errorf("originAllowed = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+
+	// Un origen rechazado responde 403 antes de suscribirse
+	router := gin.New()
+	router.GET("/api/v1/events/ws", NewEventHandler(nil, nil, response.NewResponseWriter()).StreamEventsWebSocket)
+	req := httptest.NewRequest(http.MethodGet, "http://api.taskflow.local/api/v1/events/ws", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, se esperaba 403", rec.Code)
+	}
+}
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+)
+
+// LabelHandler maneja los endpoints de etiquetas
+type LabelHandler struct {
+	labelService   *service.LabelService
+	responseWriter response.ResponseWriter
+}
+
+// NewLabelHandler crea una nueva instancia de LabelHandler
+func NewLabelHandler(labelService *service.LabelService, rw response.ResponseWriter) *LabelHandler {
+	return &LabelHandler{
+		labelService:   labelService,
+		responseWriter: rw,
+	}
+}
+
+// ListLabels godoc
+// @Summary Listar etiquetas
+// @Description Obtiene las etiquetas del workspace y las personales del usuario
+// @Tags Labels
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=[]models.Label}
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/labels [get]
+func (h *LabelHandler) ListLabels(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	labels, err := h.labelService.ListLabels(c.Request.Context(), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Etiquetas obtenidas exitosamente", labels)
+}
+
+// CreateLabel godoc
+// @Summary Crear etiqueta
+// @Description Crea una etiqueta del workspace o, con personal=true, visible solo para el usuario
+// @Tags Labels
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param request body models.CreateLabelRequest true "Datos de la etiqueta"
+// @Success 201 {object} models.APIResponse{data=models.Label}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /api/v1/labels [post]
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	var req models.CreateLabelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	label, err := h.labelService.CreateLabel(c.Request.Context(), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Etiqueta creada exitosamente", label)
+}
+
+// UpdateLabel godoc
+// @Summary Actualizar etiqueta
+// @Description Cambia nombre o color de una etiqueta (personal propia, creada por el usuario o cualquiera si es administrador)
+// @Tags Labels
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la etiqueta"
+// @Param request body models.UpdateLabelRequest true "Campos a actualizar"
+// @Success 200 {object} models.APIResponse{data=models.Label}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /api/v1/labels/{id} [patch]
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	var req models.UpdateLabelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	label, err := h.labelService.UpdateLabel(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Etiqueta actualizada exitosamente", label)
+}
+
+// DeleteLabel godoc
+// @Summary Eliminar etiqueta
+// @Description Elimina una etiqueta y la quita de todas las tareas
+// @Tags Labels
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la etiqueta"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/labels/{id} [delete]
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(c.Request.Context(), c.Param("id"), actor); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Etiqueta eliminada exitosamente", nil)
+}
+
+// AttachLabel godoc
+// @Summary Aplicar etiqueta a una tarea
+// @Description Aplica una etiqueta del workspace o personal a la tarea; aplicarla de nuevo no tiene efecto
+// @Tags Labels
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.AttachLabelRequest true "Etiqueta"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/labels [post]
+func (h *LabelHandler) AttachLabel(c *gin.Context) {
+	var req models.AttachLabelRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.labelService.AttachLabel(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Etiqueta aplicada exitosamente", task)
+}
+
+// DetachLabel godoc
+// @Summary Quitar etiqueta de una tarea
+// @Description Quita una etiqueta de la tarea
+// @Tags Labels
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param label_id path string true "ID de la etiqueta"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/labels/{label_id} [delete]
+func (h *LabelHandler) DetachLabel(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.labelService.DetachLabel(c.Request.Context(), c.Param("id"), c.Param("label_id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Etiqueta quitada exitosamente", task)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *LabelHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+	"github.com/taskflow/backend/internal/utils/validation"
+)
+
+// ProjectHandler maneja los endpoints de proyectos
+type ProjectHandler struct {
+	projectService *service.ProjectService
+	responseWriter response.ResponseWriter
+}
+
+// NewProjectHandler crea una nueva instancia de ProjectHandler
+func NewProjectHandler(projectService *service.ProjectService, rw response.ResponseWriter) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+		responseWriter: rw,
+	}
+}
+
+// CreateProject godoc
+// @Summary Crear proyecto
+// @Description Crea un proyecto en el workspace activo
+// @Tags Projects
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param request body models.CreateProjectRequest true "Datos del proyecto"
+// @Success 201 {object} models.APIResponse{data=models.Project}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/projects [post]
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var req models.CreateProjectRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	if err := validation.ValidateString(req.Name, 1, 100, "nombre"); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	project, err := h.projectService.CreateProject(c.Request.Context(), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Proyecto creado exitosamente", project)
+}
+
+// ListProjects godoc
+// @Summary Listar proyectos
+// @Description Obtiene los proyectos del workspace activo
+// @Tags Projects
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param include_archived query bool false "Incluir proyectos archivados"
+// @Success 200 {object} models.APIResponse{data=[]models.Project}
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/projects [get]
+func (h *ProjectHandler) ListProjects(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	projects, err := h.projectService.ListProjects(c.Request.Context(), actor, c.Query("include_archived") == "true")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Proyectos obtenidos exitosamente", projects)
+}
+
+// GetProject godoc
+// @Summary Obtener proyecto
+// @Description Obtiene un proyecto del workspace activo
+// @Tags Projects
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID del proyecto"
+// @Success 200 {object} models.APIResponse{data=models.Project}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/projects/{id} [get]
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	project, err := h.projectService.GetProject(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Proyecto obtenido exitosamente", project)
+}
+
+// UpdateProject godoc
+// @Summary Actualizar proyecto
+// @Description Actualiza o archiva un proyecto (dueño del proyecto o administradores)
+// @Tags Projects
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del proyecto"
+// @Param request body models.UpdateProjectRequest true "Datos a actualizar"
+// @Success 200 {object} models.APIResponse{data=models.Project}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/projects/{id} [put]
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	var req models.UpdateProjectRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	if req.Name != nil {
+		if err := validation.ValidateString(*req.Name, 1, 100, "nombre"); err != nil {
+			h.responseWriter.ValidationError(c, err.Error())
+			return
+		}
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	project, err := h.projectService.UpdateProject(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Proyecto actualizado exitosamente", project)
+}
+
+// DeleteProject godoc
+// @Summary Eliminar proyecto
+// @Description Elimina un proyecto; sus tareas quedan sin proyecto (dueño del proyecto o administradores)
+// @Tags Projects
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID del proyecto"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.projectService.DeleteProject(c.Request.Context(), c.Param("id"), actor); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Proyecto eliminado exitosamente", nil)
+}
+
+// GetProjectTasks godoc
+// @Summary Listar tareas del proyecto
+// @Description Obtiene las tareas de un proyecto con paginación (administradores ven todas; el resto las propias o asignadas)
+// @Tags Projects
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID del proyecto"
+// @Param page query int false "Número de página" default(1)
+// @Param page_size query int false "Tamaño de página" default(20)
+// @Param status query string false "Filtrar por estado" Enums(pending,in_progress,completed,cancelled)
+// @Success 200 {object} models.APIResponse{data=models.TasksListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/projects/{id}/tasks [get]
+func (h *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	// page, page_size y status ya fueron normalizados por ValidationMiddleware y SanitizeQueryParams
+	resp, err := h.projectService.GetProjectTasks(
+		c.Request.Context(), c.Param("id"), c.GetString("status"), c.GetInt("page"), c.GetInt("page_size"), actor,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Tareas del proyecto obtenidas exitosamente", resp)
+}
+
+// GetProjectStats godoc
+// @Summary Obtener estadísticas del proyecto
+// @Description Obtiene estadísticas de las tareas del proyecto visibles para el usuario
+// @Tags Projects
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID del proyecto"
+// @Success 200 {object} models.APIResponse{data=models.TaskStats}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/projects/{id}/stats [get]
+func (h *ProjectHandler) GetProjectStats(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	stats, err := h.projectService.GetProjectStats(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Estadísticas del proyecto obtenidas exitosamente", stats)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *ProjectHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
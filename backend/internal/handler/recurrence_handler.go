@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+)
+
+// RecurrenceHandler maneja los endpoints de repetición de tareas
+type RecurrenceHandler struct {
+	recurrenceService *service.RecurrenceService
+	responseWriter    response.ResponseWriter
+}
+
+// NewRecurrenceHandler crea una nueva instancia de RecurrenceHandler
+func NewRecurrenceHandler(recurrenceService *service.RecurrenceService, rw response.ResponseWriter) *RecurrenceHandler {
+	return &RecurrenceHandler{
+		recurrenceService: recurrenceService,
+		responseWriter:    rw,
+	}
+}
+
+// GetRecurrence godoc
+// @Summary Obtener regla de repetición
+// @Description Obtiene la regla de repetición de la que forma parte la tarea, con el vencimiento de la próxima ocurrencia
+// @Tags Recurrence
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse{data=models.TaskRecurrence}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/recurrence [get]
+func (h *RecurrenceHandler) GetRecurrence(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	rule, err := h.recurrenceService.GetRecurrence(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Regla de repetición obtenida exitosamente", rule)
+}
+
+// SetRecurrence godoc
+// @Summary Definir regla de repetición
+// @Description Hace que la tarea se repita cada día, en días de la semana o en un día del mes, hasta ends_at o max_occurrences. La fecha de vencimiento de la tarea es el inicio de la serie; la siguiente ocurrencia se crea cuando la anterior vence o se completa
+// @Tags Recurrence
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.SetRecurrenceRequest true "Regla de repetición"
+// @Success 200 {object} models.APIResponse{data=models.TaskRecurrence}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/recurrence [put]
+func (h *RecurrenceHandler) SetRecurrence(c *gin.Context) {
+	var req models.SetRecurrenceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	rule, err := h.recurrenceService.SetRecurrence(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Regla de repetición guardada exitosamente", rule)
+}
+
+// DeleteRecurrence godoc
+// @Summary Detener repetición
+// @Description Elimina la regla de repetición de la tarea; las ocurrencias ya creadas se conservan
+// @Tags Recurrence
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/recurrence [delete]
+func (h *RecurrenceHandler) DeleteRecurrence(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	if err := h.recurrenceService.DeleteRecurrence(c.Request.Context(), c.Param("id"), actor); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Repetición eliminada exitosamente", nil)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *RecurrenceHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
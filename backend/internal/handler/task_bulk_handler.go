@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+)
+
+// BulkTaskHandler maneja las operaciones masivas sobre tareas
+type BulkTaskHandler struct {
+	bulkService    *service.BulkTaskService
+	responseWriter response.ResponseWriter
+}
+
+// NewBulkTaskHandler crea una nueva instancia de BulkTaskHandler
+func NewBulkTaskHandler(bulkService *service.BulkTaskService, rw response.ResponseWriter) *BulkTaskHandler {
+	return &BulkTaskHandler{
+		bulkService:    bulkService,
+		responseWriter: rw,
+	}
+}
+
+// BulkUpdate godoc
+// @Summary Operación masiva sobre tareas
+// @Description Cambia el estado, asigna, cambia la prioridad, elimina o etiqueta varias tareas. Cada tarea se autoriza por separado y tiene su propio resultado; con atomic=true, si alguna falla no se aplica a ninguna y responde 409 con los resultados de cada tarea en data
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param request body models.BulkTaskRequest true "Operación y tareas"
+// @Param Idempotency-Key header string false "Clave para reintentar sin duplicar: repite la respuesta original"
+// @Success 200 {object} models.APIResponse{data=models.BulkTaskResponse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse{data=models.BulkTaskResponse}
+// @Router /api/v1/tasks/bulk [post]
+func (h *BulkTaskHandler) BulkUpdate(c *gin.Context) {
+	var req models.BulkTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	resp, err := h.bulkService.Apply(c.Request.Context(), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if resp.RolledBack {
+		h.responseWriter.Conflict(c, "La operación masiva no se aplicó: falló una de las tareas", resp)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Operación masiva procesada", resp)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *BulkTaskHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+)
+
+const (
+	bulkTaskFound   = "6f1c2b8e-8a43-4c1e-9d6a-2f4b7c9e1a30"
+	bulkTaskMissing = "0b7d4f2a-51c9-4e8b-a3f6-9c2e1d8b7a54"
+)
+
+func TestBulkUpdateStatusCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	taskRepo := &service.MockTaskRepository{
+		GetByIDFunc: func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+			if id != bulkTaskFound {
+				return nil, fmt.Errorf("tarea no encontrada")
+			}
+			return &models.Task{ID: id, WorkspaceID: workspaceID, Status: models.TaskStatusPending, CreatedBy: "user-1", Version: 1}, nil
+		},
+	}
+	events := service.NewEventHub(&service.MockTaskChangeRepository{}, &service.MockWorkspaceRepository{}, &service.MockUserRepository{}, time.Hour)
+	taskService := service.NewTaskService(taskRepo, &service.MockProjectRepository{}, &service.MockWorkspaceRepository{}, &service.MockDependencyRepository{}, &service.MockUserRepository{}, &service.MockTransactor{}, events)
+	bulkService := service.NewBulkTaskService(taskService, service.NewLabelService(&service.MockLabelRepository{}, taskRepo), &service.MockTransactor{}, 100)
+
+	router := gin.New()
+	router.POST("/tasks/bulk", func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Set("role", models.RoleMember)
+		c.Set("workspace_id", "ws-1")
+		c.Set("workspace_role", models.WorkspaceRoleMember)
+	}, NewBulkTaskHandler(bulkService, response.NewResponseWriter()).BulkUpdate)
+
+	tests := []struct {
+		name       string
+		atomic     bool
+		wantStatus int
+	}{
+		{name: "sin atomic informa cada tarea con 200", atomic: false, wantStatus: http.StatusOK},
+		{name: "atomic revertido responde 409", atomic: true, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(models.BulkTaskRequest{
+				Operation: models.BulkOpUpdateStatus,
+				TaskIDs:   []string{bulkTaskFound, bulkTaskMissing},
+				Status:    models.TaskStatusInProgress,
+				Atomic:    tt.atomic,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/tasks/bulk", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, se esperaba %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+
+			var resp struct {
+				Data models.BulkTaskResponse `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("respuesta inválida: %v", err)
+			}
+			if len(resp.Data.Results) != 2 || resp.Data.RolledBack != tt.atomic {
+				t.Errorf("data = %+v, se esperaban los 2 resultados y rolled_back=%v", resp.Data, tt.atomic)
+			}
+		})
+	}
+}
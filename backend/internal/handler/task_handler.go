@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/taskflow/backend/internal/errors"
@@ -32,9 +35,11 @@ func NewTaskHandler(taskService *service.TaskService, rw response.ResponseWriter
 // @Description Crea una nueva tarea para el usuario autenticado
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Accept json
 // @Produce json
 // @Param request body models.CreateTaskRequest true "Datos de la tarea"
+// @Param Idempotency-Key header string false "Clave para reintentar sin duplicar: repite la respuesta original"
 // @Success 201 {object} models.APIResponse{data=models.Task}
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
@@ -78,15 +83,15 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 
 	fmt.Printf("✅ JSON parsed: title=%s, priority=%s, dueDate=%v\n", req.Title, req.Priority, req.DueDate)
 
-	userID, exists := c.Get("user_id")
-	if !exists {
+	actor, ok := actorFromContext(c)
+	if !ok {
 		fmt.Printf("🔴 ERROR en CreateTask Handler - No user_id in context\n")
 		h.responseWriter.Unauthorized(c, "No autorizado")
 		return
 	}
 
-	fmt.Printf("📝 CreateTask Handler - Calling service with userID=%v\n", userID)
-	task, err := h.taskService.CreateTask(c.Request.Context(), &req, userID.(string))
+	fmt.Printf("📝 CreateTask Handler - Calling service with userID=%s, workspaceID=%s\n", actor.UserID, actor.WorkspaceID)
+	task, err := h.taskService.CreateTask(c.Request.Context(), &req, actor)
 	if err != nil {
 		fmt.Printf("🔴 ERROR en CreateTask Handler - Service Error: %v (type: %T)\n", err, err)
 		h.handleError(c, err)
@@ -99,15 +104,32 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 
 // GetTasks godoc
 // @Summary Listar todas las tareas
-// @Description Obtiene todas las tareas del sistema con paginación (sin filtro por usuario)
+// @Description Obtiene todas las tareas del workspace activo con paginación (sin filtro por usuario, solo administradores del sistema o del workspace)
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Produce json
 // @Param page query int false "Número de página" default(1)
 // @Param page_size query int false "Tamaño de página" default(20)
 // @Param status query string false "Filtrar por estado" Enums(pending,in_progress,completed,cancelled)
+// @Param priority query string false "Filtrar por prioridad" Enums(low,medium,high,urgent)
+// @Param assigned_to query string false "Filtrar por responsable (cualquiera de los asignados)"
+// @Param created_by query string false "Filtrar por creador"
+// @Param unassigned query bool false "Solo tareas sin asignar"
+// @Param overdue query bool false "Solo tareas vencidas y abiertas"
+// @Param due_from query string false "Vencimiento desde (YYYY-MM-DD o RFC3339)"
+// @Param due_to query string false "Vencimiento hasta (YYYY-MM-DD o RFC3339)"
+// @Param q query string false "Búsqueda de texto en título y descripción"
+// @Param labels query string false "Nombres de etiquetas separados por coma"
+// @Param label_match query string false "Coincidencia de etiquetas: cualquiera o todas" Enums(any,all) default(any)
+// @Param sort query string false "Campo de orden (por defecto created_at descendente, o relevancia si hay q)" Enums(created_at,due_date,priority,updated_at,title)
+// @Param order query string false "Dirección del orden" Enums(asc,desc) default(asc)
+// @Param cursor query string false "Paginación por cursor: vacío para la primera página, luego el next_cursor recibido (ignora page). No se combina con q: la búsqueda ordena por relevancia y se pagina con page"
+// @Param include_total query bool false "En modo cursor, incluir el total de tareas"
 // @Success 200 {object} models.APIResponse{data=models.TasksListResponse}
+// @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Router /api/v1/tasks [get]
 func (h *TaskHandler) GetTasks(c *gin.Context) {
 	defer func() {
@@ -131,12 +153,27 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 		}
 	}
 
-	status := c.Query("status")
+	filter, sort, err := parseTaskListQuery(c)
+	if err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
 
-	fmt.Printf("📝 GetTasks Handler - Calling service with page=%d, pageSize=%d, status=%s (ALL TASKS)\n", page, pageSize, status)
+	fmt.Printf("📝 GetTasks Handler - Calling service with page=%d, pageSize=%d, filter=%+v, sort=%+v (ALL TASKS)\n", page, pageSize, filter, sort)
 
-	// Pasar userID vacío para obtener todas las tareas
-	resp, err := h.taskService.GetTasks(c.Request.Context(), "", status, page, pageSize)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	// Sin filtro por usuario para obtener todas las tareas del workspace
+	var resp *models.TasksListResponse
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		resp, err = h.taskService.GetWorkspaceTasksByCursor(c.Request.Context(), filter, sort, cursor, pageSize, c.Query("include_total") == "true", actor)
+	} else {
+		resp, err = h.taskService.GetWorkspaceTasks(c.Request.Context(), filter, sort, page, pageSize, actor)
+	}
 	if err != nil {
 		fmt.Printf("🔴 ERROR en GetTasks Handler - Service Error: %v (type: %T)\n", err, err)
 		h.handleError(c, err)
@@ -149,14 +186,30 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 
 // GetMyTasks godoc
 // @Summary Listar mis tareas
-// @Description Obtiene las tareas creadas por o asignadas al usuario autenticado con paginación
+// @Description Obtiene las tareas creadas por el usuario autenticado o de las que es uno de los responsables, con paginación
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Produce json
 // @Param page query int false "Número de página" default(1)
 // @Param page_size query int false "Tamaño de página" default(20)
 // @Param status query string false "Filtrar por estado" Enums(pending,in_progress,completed,cancelled)
+// @Param priority query string false "Filtrar por prioridad" Enums(low,medium,high,urgent)
+// @Param assigned_to query string false "Filtrar por responsable (cualquiera de los asignados)"
+// @Param created_by query string false "Filtrar por creador"
+// @Param unassigned query bool false "Solo tareas sin asignar"
+// @Param overdue query bool false "Solo tareas vencidas y abiertas"
+// @Param due_from query string false "Vencimiento desde (YYYY-MM-DD o RFC3339)"
+// @Param due_to query string false "Vencimiento hasta (YYYY-MM-DD o RFC3339)"
+// @Param q query string false "Búsqueda de texto en título y descripción"
+// @Param labels query string false "Nombres de etiquetas separados por coma"
+// @Param label_match query string false "Coincidencia de etiquetas: cualquiera o todas" Enums(any,all) default(any)
+// @Param sort query string false "Campo de orden (por defecto created_at descendente, o relevancia si hay q)" Enums(created_at,due_date,priority,updated_at,title)
+// @Param order query string false "Dirección del orden" Enums(asc,desc) default(asc)
+// @Param cursor query string false "Paginación por cursor: vacío para la primera página, luego el next_cursor recibido (ignora page). No se combina con q: la búsqueda ordena por relevancia y se pagina con page"
+// @Param include_total query bool false "En modo cursor, incluir el total de tareas"
 // @Success 200 {object} models.APIResponse{data=models.TasksListResponse}
+// @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
 // @Router /api/v1/tasks/my [get]
 func (h *TaskHandler) GetMyTasks(c *gin.Context) {
@@ -190,11 +243,16 @@ func (h *TaskHandler) GetMyTasks(c *gin.Context) {
 		}
 	}
 
-	status := c.Query("status")
+	filter, sort, err := parseTaskListQuery(c)
+	if err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+	filter.UserID = userID.(string)
 
-	fmt.Printf("📝 GetMyTasks Handler - Calling service with page=%d, pageSize=%d, status=%s\n", page, pageSize, status)
+	fmt.Printf("📝 GetMyTasks Handler - Calling service with page=%d, pageSize=%d, filter=%+v, sort=%+v\n", page, pageSize, filter, sort)
 
-	resp, err := h.taskService.GetTasks(c.Request.Context(), userID.(string), status, page, pageSize)
+	resp, err := h.listTasks(c, filter, sort, page, pageSize)
 	if err != nil {
 		fmt.Printf("🔴 ERROR en GetMyTasks Handler - Service Error: %v (type: %T)\n", err, err)
 		h.handleError(c, err)
@@ -207,13 +265,16 @@ func (h *TaskHandler) GetMyTasks(c *gin.Context) {
 
 // GetTask godoc
 // @Summary Obtener tarea por ID
-// @Description Obtiene los detalles de una tarea específica
+// @Description Obtiene los detalles de una tarea específica. El header ETag contiene su versión para usarla en If-Match
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Produce json
 // @Param id path string true "ID de la tarea"
 // @Success 200 {object} models.APIResponse{data=models.Task}
+// @Header 200 {string} ETag "Versión de la tarea"
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
 // @Router /api/v1/tasks/{id} [get]
 func (h *TaskHandler) GetTask(c *gin.Context) {
@@ -230,12 +291,19 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskService.GetTaskByID(c.Request.Context(), taskID)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.GetTaskByID(c.Request.Context(), taskID, actor)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
+	setTaskETag(c, task)
 	h.responseWriter.Success(c, http.StatusOK, "Tarea obtenida exitosamente", task)
 }
 
@@ -244,14 +312,19 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 // @Description Actualiza los detalles de una tarea
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Accept json
 // @Produce json
 // @Param id path string true "ID de la tarea"
+// @Param If-Match header string false "ETag obtenido al leer la tarea; si cambió desde entonces responde 412. Acepta *, una lista separada por comas y ETags débiles (W/), que se comparan por su valor"
 // @Param request body models.UpdateTaskRequest true "Datos a actualizar"
 // @Success 200 {object} models.APIResponse{data=models.Task}
+// @Header 200 {string} ETag "Nueva versión de la tarea"
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 412 {object} models.APIResponse{data=models.Task} "La tarea cambió; data contiene la versión actual"
 // @Router /api/v1/tasks/{id} [put]
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	taskID := c.Param("id")
@@ -275,7 +348,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	}
 
 	// Validar campos opcionales
-	if req.Title != nil && *req.Title != "" {
+	if req.Title != nil {
 		if err := validation.ValidateTaskTitle(*req.Title); err != nil {
 			h.responseWriter.ValidationError(c, fmt.Sprintf("Título: %v", err))
 			return
@@ -296,25 +369,109 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		}
 	}
 
-	task, err := h.taskService.UpdateTask(c.Request.Context(), taskID, &req)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	versions, err := ifMatchVersions(c)
 	if err != nil {
-		h.handleError(c, err)
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	task, err := h.taskService.UpdateTask(c.Request.Context(), taskID, &req, versions, actor)
+	if err != nil {
+		h.handleWriteError(c, taskID, actor, err)
+		return
+	}
+
+	setTaskETag(c, task)
+	h.responseWriter.Success(c, http.StatusOK, "Tarea actualizada exitosamente", task)
+}
+
+// PatchTask godoc
+// @Summary Actualizar parcialmente tarea
+// @Description Aplica un JSON Merge Patch (RFC 7396): solo se modifican los campos presentes y null limpia description o due_date
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param If-Match header string false "ETag obtenido al leer la tarea; si cambió desde entonces responde 412. Acepta *, una lista separada por comas y ETags débiles (W/), que se comparan por su valor"
+// @Param request body models.UpdateTaskRequest true "Campos a modificar"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Header 200 {string} ETag "Nueva versión de la tarea"
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 412 {object} models.APIResponse{data=models.Task} "La tarea cambió; data contiene la versión actual"
+// @Failure 415 {object} models.APIResponse
+// @Router /api/v1/tasks/{id} [patch]
+func (h *TaskHandler) PatchTask(c *gin.Context) {
+	taskID := c.Param("id")
+
+	if err := validation.ValidateUUID(taskID); err != nil {
+		h.responseWriter.ValidationError(c, fmt.Sprintf("ID inválido: %v", err))
+		return
+	}
+
+	if contentType := c.ContentType(); contentType != "application/merge-patch+json" && contentType != "application/json" {
+		h.responseWriter.Error(c, http.StatusUnsupportedMediaType, "Content-Type debe ser application/merge-patch+json")
 		return
 	}
 
+	body, err := c.GetRawData()
+	if err != nil {
+		h.responseWriter.ValidationError(c, fmt.Sprintf("No se pudo leer el body: %v", err))
+		return
+	}
+
+	patch, err := parseTaskPatch(body)
+	if err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	versions, err := ifMatchVersions(c)
+	if err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	task, err := h.taskService.PatchTask(c.Request.Context(), taskID, patch, versions, actor)
+	if err != nil {
+		h.handleWriteError(c, taskID, actor, err)
+		return
+	}
+
+	setTaskETag(c, task)
 	h.responseWriter.Success(c, http.StatusOK, "Tarea actualizada exitosamente", task)
 }
 
 // DeleteTask godoc
 // @Summary Eliminar tarea
-// @Description Elimina una tarea del sistema
+// @Description Mueve la tarea a la papelera; se puede restaurar hasta que se elimine definitivamente al vencer la retención
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Produce json
 // @Param id path string true "ID de la tarea"
+// @Param If-Match header string false "ETag obtenido al leer la tarea; si cambió desde entonces responde 412. Acepta *, una lista separada por comas y ETags débiles (W/), que se comparan por su valor"
 // @Success 200 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 412 {object} models.APIResponse{data=models.Task} "La tarea cambió; data contiene la versión actual"
 // @Router /api/v1/tasks/{id} [delete]
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	defer func() {
@@ -332,12 +489,25 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	fmt.Printf("📝 DeleteTask Handler - taskID=%s\n", taskID)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		fmt.Printf("🔴 ERROR en DeleteTask Handler - No user_id in context\n")
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	versions, err := ifMatchVersions(c)
+	if err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
 
-	err := h.taskService.DeleteTask(c.Request.Context(), taskID)
+	fmt.Printf("📝 DeleteTask Handler - taskID=%s, userID=%s\n", taskID, actor.UserID)
+
+	err = h.taskService.DeleteTask(c.Request.Context(), taskID, versions, actor)
 	if err != nil {
 		fmt.Printf("🔴 ERROR en DeleteTask Handler - Service Error: %v (type: %T)\n", err, err)
-		h.handleError(c, err)
+		h.handleWriteError(c, taskID, actor, err)
 		return
 	}
 
@@ -345,19 +515,83 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	h.responseWriter.Success(c, http.StatusOK, "Tarea eliminada exitosamente", nil)
 }
 
+// GetTrash godoc
+// @Summary Listar papelera
+// @Description Obtiene las tareas eliminadas, de la más reciente a la más antigua. Los administradores ven todas; el resto solo las que crearon
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param page query int false "Número de página" default(1)
+// @Param page_size query int false "Tamaño de página" default(20)
+// @Success 200 {object} models.APIResponse{data=models.TasksListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/tasks/trash [get]
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	// page y page_size ya fueron normalizados por ValidationMiddleware
+	resp, err := h.taskService.GetTrash(c.Request.Context(), c.GetInt("page"), c.GetInt("page_size"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Papelera obtenida exitosamente", resp)
+}
+
+// RestoreTask godoc
+// @Summary Restaurar tarea
+// @Description Saca una tarea de la papelera (creador o administradores)
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/restore [post]
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.RestoreTask(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Tarea restaurada exitosamente", task)
+}
+
 // UpdateTaskStatus godoc
 // @Summary Actualizar estado de tarea
-// @Description Cambia el estado de una tarea
+// @Description Cambia el estado de una tarea según las transiciones permitidas en el workspace (409 si la transición no está permitida, si la tarea tiene bloqueadores abiertos al iniciarla o completarla, o si se completa con subtareas abiertas sin allow_open_subtasks)
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Accept json
 // @Produce json
 // @Param id path string true "ID de la tarea"
+// @Param If-Match header string false "ETag obtenido al leer la tarea; si cambió desde entonces responde 412. Acepta *, una lista separada por comas y ETags débiles (W/), que se comparan por su valor"
 // @Param request body models.UpdateTaskStatusRequest true "Nuevo estado"
 // @Success 200 {object} models.APIResponse{data=models.Task}
+// @Header 200 {string} ETag "Nueva versión de la tarea"
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Failure 412 {object} models.APIResponse{data=models.Task} "La tarea cambió; data contiene la versión actual"
 // @Router /api/v1/tasks/{id}/status [patch]
 func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 	taskID := c.Param("id")
@@ -386,20 +620,111 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskService.UpdateTaskStatus(c.Request.Context(), taskID, &req)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	versions, err := ifMatchVersions(c)
+	if err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	task, warning, err := h.taskService.UpdateTaskStatus(c.Request.Context(), taskID, &req, versions, actor)
+	if err != nil {
+		h.handleWriteError(c, taskID, actor, err)
+		return
+	}
+
+	message := "Estado de tarea actualizado exitosamente"
+	if warning != "" {
+		message = warning
+	}
+
+	setTaskETag(c, task)
+	h.responseWriter.Success(c, http.StatusOK, message, task)
+}
+
+// SetParentTask godoc
+// @Summary Mover tarea bajo otra tarea
+// @Description Convierte la tarea en subtarea de parent_task_id, o en tarea raíz si es null. Rechaza ciclos con 422
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.SetParentTaskRequest true "Nueva tarea padre"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/parent [put]
+func (h *TaskHandler) SetParentTask(c *gin.Context) {
+	var req models.SetParentTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.SetParentTask(c.Request.Context(), c.Param("id"), &req, actor)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	h.responseWriter.Success(c, http.StatusOK, "Estado de tarea actualizado exitosamente", task)
+	h.responseWriter.Success(c, http.StatusOK, "Tarea padre actualizada exitosamente", task)
+}
+
+// GetSubtasks godoc
+// @Summary Listar subtareas
+// @Description Obtiene las subtareas directas de una tarea con paginación
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param page query int false "Número de página" default(1)
+// @Param page_size query int false "Tamaño de página" default(20)
+// @Success 200 {object} models.APIResponse{data=models.TasksListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/subtasks [get]
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	// page y page_size ya fueron normalizados por ValidationMiddleware
+	resp, err := h.taskService.GetSubtasks(c.Request.Context(), c.Param("id"), c.GetInt("page"), c.GetInt("page_size"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Subtareas obtenidas exitosamente", resp)
 }
 
 // AssignTask godoc
 // @Summary Asignar tarea a usuario
-// @Description Asigna una tarea a un usuario específico
+// @Description Reemplaza al responsable principal (assigned_to) y registra quién lo asignó y cuándo; los demás responsables se conservan. Con assigned_to null se quitan todos. El usuario debe existir (404) y ser miembro del workspace sin rol de solo lectura (422)
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Accept json
 // @Produce json
 // @Param id path string true "ID de la tarea"
@@ -407,7 +732,9 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 // @Success 200 {object} models.APIResponse{data=models.Task}
 // @Failure 400 {object} models.APIResponse
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
 // @Router /api/v1/tasks/{id}/assign [post]
 func (h *TaskHandler) AssignTask(c *gin.Context) {
 	taskID := c.Param("id")
@@ -424,7 +751,43 @@ func (h *TaskHandler) AssignTask(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskService.AssignTask(c.Request.Context(), taskID, &req)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.AssignTask(c.Request.Context(), taskID, &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Tarea asignada exitosamente", task)
+}
+
+// AssignToMe godoc
+// @Summary Asignarme la tarea
+// @Description Agrega al usuario autenticado como responsable; pasa a ser el principal si la tarea no tenía. Requiere poder asignar la tarea, como agregar a otro responsable
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/assign/me [post]
+func (h *TaskHandler) AssignToMe(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.AssignToMe(c.Request.Context(), c.Param("id"), actor)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -433,11 +796,228 @@ func (h *TaskHandler) AssignTask(c *gin.Context) {
 	h.responseWriter.Success(c, http.StatusOK, "Tarea asignada exitosamente", task)
 }
 
+// UnassignMe godoc
+// @Summary Desasignarme la tarea
+// @Description Quita al usuario autenticado de los responsables; si era el principal lo reemplaza el responsable restante más antiguo
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/assign/me [delete]
+func (h *TaskHandler) UnassignMe(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.UnassignMe(c.Request.Context(), c.Param("id"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Tarea desasignada exitosamente", task)
+}
+
+// AddAssignee godoc
+// @Summary Agregar responsable
+// @Description Agrega un responsable a la tarea; si no tenía, pasa a ser el principal (assigned_to)
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.AddAssigneeRequest true "Usuario responsable"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/assignees [post]
+func (h *TaskHandler) AddAssignee(c *gin.Context) {
+	var req models.AddAssigneeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.AddAssignee(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Responsable agregado exitosamente", task)
+}
+
+// RemoveAssignee godoc
+// @Summary Quitar responsable
+// @Description Quita un responsable de la tarea; si era el principal lo reemplaza el responsable restante más antiguo
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param user_id path string true "ID del usuario"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/assignees/{user_id} [delete]
+func (h *TaskHandler) RemoveAssignee(c *gin.Context) {
+	userID := c.Param("user_id")
+	if err := validation.ValidateUUID(userID); err != nil {
+		h.responseWriter.ValidationError(c, fmt.Sprintf("user_id: %v", err))
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.RemoveAssignee(c.Request.Context(), c.Param("id"), userID, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Responsable quitado exitosamente", task)
+}
+
+// AddWatcher godoc
+// @Summary Seguir tarea
+// @Description Agrega un seguidor a la tarea (por defecto el usuario autenticado). Los seguidores pueden ver la tarea; agregar a otro usuario requiere poder asignarla
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Accept json
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param request body models.AddWatcherRequest false "Usuario seguidor"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/watchers [post]
+func (h *TaskHandler) AddWatcher(c *gin.Context) {
+	var req models.AddWatcherRequest
+
+	// El body es opcional: sin él sigue la tarea el propio usuario
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.responseWriter.ValidationError(c, err.Error())
+			return
+		}
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.AddWatcher(c.Request.Context(), c.Param("id"), &req, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Seguidor agregado exitosamente", task)
+}
+
+// RemoveWatcher godoc
+// @Summary Dejar de seguir tarea
+// @Description Quita un seguidor de la tarea. Cada usuario puede quitarse a sí mismo; quitar a otro requiere poder asignarla
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param user_id path string true "ID del usuario"
+// @Success 200 {object} models.APIResponse{data=models.Task}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/watchers/{user_id} [delete]
+func (h *TaskHandler) RemoveWatcher(c *gin.Context) {
+	userID := c.Param("user_id")
+	if err := validation.ValidateUUID(userID); err != nil {
+		h.responseWriter.ValidationError(c, fmt.Sprintf("user_id: %v", err))
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	task, err := h.taskService.RemoveWatcher(c.Request.Context(), c.Param("id"), userID, actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Seguidor quitado exitosamente", task)
+}
+
+// GetTaskActivity godoc
+// @Summary Historial de actividad de una tarea
+// @Description Obtiene quién cambió qué campo de la tarea y cuándo, del cambio más reciente al más antiguo
+// @Tags Tasks
+// @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
+// @Produce json
+// @Param id path string true "ID de la tarea"
+// @Param page query int false "Número de página" default(1)
+// @Param page_size query int false "Tamaño de página" default(20)
+// @Success 200 {object} models.APIResponse{data=models.TaskEventsListResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/tasks/{id}/activity [get]
+func (h *TaskHandler) GetTaskActivity(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	// page y page_size ya fueron normalizados por ValidationMiddleware
+	resp, err := h.taskService.GetTaskActivity(c.Request.Context(), c.Param("id"), c.GetInt("page"), c.GetInt("page_size"), actor)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Historial de la tarea obtenido exitosamente", resp)
+}
+
 // GetTaskStats godoc
 // @Summary Obtener estadísticas de tareas
-// @Description Obtiene estadísticas de tareas del usuario autenticado
+// @Description Obtiene estadísticas de las tareas creadas por el usuario autenticado o de las que es responsable
 // @Tags Tasks
 // @Security Bearer
+// @Param X-Workspace-ID header string false "ID del workspace activo (por defecto el del usuario)"
 // @Produce json
 // @Success 200 {object} models.APIResponse{data=models.TaskStats}
 // @Failure 401 {object} models.APIResponse
@@ -449,7 +1029,7 @@ func (h *TaskHandler) GetTaskStats(c *gin.Context) {
 		return
 	}
 
-	stats, err := h.taskService.GetTaskStats(c.Request.Context(), userID.(string))
+	stats, err := h.taskService.GetTaskStats(c.Request.Context(), c.GetString("workspace_id"), userID.(string))
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -461,9 +1041,270 @@ func (h *TaskHandler) GetTaskStats(c *gin.Context) {
 // handleError maneja los errores de la aplicación
 func (h *TaskHandler) handleError(c *gin.Context, err error) {
 	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
 		h.responseWriter.Error(c, appErr.Code, appErr.Message)
 		return
 	}
 
 	h.responseWriter.InternalError(c, err.Error())
 }
+
+// handleWriteError responde como handleError, salvo un conflicto de versión: en ese
+// caso responde 412 con la tarea actual y su ETag para que el cliente pueda reintentar
+func (h *TaskHandler) handleWriteError(c *gin.Context, taskID string, actor *models.Actor, err error) {
+	if err != errors.ErrTaskVersionConflict {
+		h.handleError(c, err)
+		return
+	}
+
+	current, getErr := h.taskService.GetTaskByID(c.Request.Context(), taskID, actor)
+	if getErr != nil {
+		h.handleError(c, getErr)
+		return
+	}
+
+	setTaskETag(c, current)
+	h.responseWriter.PreconditionFailed(c, errors.ErrTaskVersionConflict.Message, current)
+}
+
+// setTaskETag expone la versión de la tarea como ETag fuerte
+func setTaskETag(c *gin.Context, task *models.Task) {
+	c.Header("ETag", `"`+strconv.Itoa(task.Version)+`"`)
+}
+
+// ifMatchVersions obtiene las versiones aceptadas del header If-Match: uno o más
+// ETags separados por comas, fuertes ("3") o débiles (W/"3"), que se comparan por su
+// valor. Sin header o con * no se verifica la versión
+func ifMatchVersions(c *gin.Context) ([]int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return nil, nil
+	}
+
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, nil
+		}
+
+		version, ok := parseTaskETag(tag)
+		if !ok {
+			return nil, fmt.Errorf("If-Match inválido: se espera el ETag de la tarea, por ejemplo \"3\"")
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// parseTaskETag obtiene la versión de un ETag de tarea, fuerte o débil
+func parseTaskETag(tag string) (int, bool) {
+	tag = strings.TrimPrefix(tag, "W/")
+	if len(tag) < 3 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// parseTaskPatch interpreta un JSON Merge Patch sobre una tarea y valida cada campo
+// presente. Los campos ausentes no forman parte del resultado
+func parseTaskPatch(body []byte) (models.TaskPatch, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("el body debe ser un objeto JSON")
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	patch := models.TaskPatch{}
+	for _, name := range names {
+		nullable, ok := models.TaskPatchFields[name]
+		if !ok {
+			return nil, fmt.Errorf("campo no modificable: %s", name)
+		}
+
+		if string(fields[name]) == "null" {
+			if !nullable {
+				return nil, fmt.Errorf("%s no puede ser null", name)
+			}
+			patch[name] = nil
+			continue
+		}
+
+		var value string
+		if err := json.Unmarshal(fields[name], &value); err != nil {
+			return nil, fmt.Errorf("%s debe ser texto", name)
+		}
+
+		switch name {
+		case "title":
+			if err := validation.ValidateTaskTitle(value); err != nil {
+				return nil, fmt.Errorf("Título: %v", err)
+			}
+			patch[name] = value
+		case "description":
+			if err := validation.ValidateTaskDescription(value); err != nil {
+				return nil, fmt.Errorf("Descripción: %v", err)
+			}
+			patch[name] = value
+		case "priority":
+			if err := validation.ValidatePriority(value); err != nil {
+				return nil, fmt.Errorf("Prioridad: %v", err)
+			}
+			patch[name] = value
+		case "due_date":
+			dueDate, err := validation.ParseDueDate(value)
+			if err != nil {
+				return nil, err
+			}
+			patch[name] = dueDate
+		}
+	}
+
+	return patch, nil
+}
+
+// actorFromContext construye el actor autenticado a partir de los datos que deja AuthMiddleware
+func actorFromContext(c *gin.Context) (*models.Actor, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		return nil, false
+	}
+
+	return &models.Actor{
+		UserID:        userID,
+		Role:          c.GetString("role"),
+		WorkspaceID:   c.GetString("workspace_id"),
+		WorkspaceRole: c.GetString("workspace_role"),
+	}, true
+}
+
+// listTasks usa paginación por cursor si el request incluye el parámetro cursor
+// (aunque esté vacío) y paginación por página en caso contrario
+func (h *TaskHandler) listTasks(c *gin.Context, filter models.TaskFilter, sort models.TaskSort, page, pageSize int) (*models.TasksListResponse, error) {
+	workspaceID := c.GetString("workspace_id")
+
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		return h.taskService.GetTasksByCursor(c.Request.Context(), workspaceID, filter, sort, cursor, pageSize, c.Query("include_total") == "true")
+	}
+
+	return h.taskService.GetTasks(c.Request.Context(), workspaceID, filter, sort, page, pageSize)
+}
+
+// maxLabelFilters cantidad máxima de etiquetas en el filtro labels
+const maxLabelFilters = 20
+
+// parseTaskListQuery interpreta los filtros y el orden de los listados de tareas
+func parseTaskListQuery(c *gin.Context) (models.TaskFilter, models.TaskSort, error) {
+	// Las etiquetas personales de otros usuarios no se muestran ni cuentan en el
+	// filtro aunque coincida el nombre
+	filter := models.TaskFilter{LabelOwner: c.GetString("user_id")}
+	var sort models.TaskSort
+
+	if status := c.Query("status"); status != "" {
+		if err := validation.ValidateStatus(status); err != nil {
+			return filter, sort, err
+		}
+		filter.Status = status
+	}
+
+	if priority := c.Query("priority"); priority != "" {
+		if err := validation.ValidatePriority(priority); err != nil {
+			return filter, sort, err
+		}
+		filter.Priority = priority
+	}
+
+	if assignedTo := c.Query("assigned_to"); assignedTo != "" {
+		if err := validation.ValidateUUID(assignedTo); err != nil {
+			return filter, sort, fmt.Errorf("assigned_to: %v", err)
+		}
+		filter.AssignedTo = assignedTo
+	}
+
+	if createdBy := c.Query("created_by"); createdBy != "" {
+		if err := validation.ValidateUUID(createdBy); err != nil {
+			return filter, sort, fmt.Errorf("created_by: %v", err)
+		}
+		filter.CreatedBy = createdBy
+	}
+
+	filter.Unassigned = c.Query("unassigned") == "true"
+	filter.Overdue = c.Query("overdue") == "true"
+
+	if dueFrom := c.Query("due_from"); dueFrom != "" {
+		t, err := validation.ParseDate(dueFrom, false)
+		if err != nil {
+			return filter, sort, fmt.Errorf("due_from: %v", err)
+		}
+		filter.DueFrom = &t
+	}
+
+	if dueTo := c.Query("due_to"); dueTo != "" {
+		t, err := validation.ParseDate(dueTo, true)
+		if err != nil {
+			return filter, sort, fmt.Errorf("due_to: %v", err)
+		}
+		filter.DueTo = &t
+	}
+
+	if q := validation.SanitizeString(c.Query("q")); q != "" {
+		if err := validation.ValidateString(q, 0, 100, "q"); err != nil {
+			return filter, sort, err
+		}
+		filter.Query = q
+	}
+
+	if labels := c.Query("labels"); labels != "" {
+		for _, label := range strings.Split(labels, ",") {
+			name := strings.TrimSpace(validation.SanitizeString(label))
+			if name == "" {
+				continue
+			}
+			if err := validation.ValidateString(name, 1, 50, "labels"); err != nil {
+				return filter, sort, err
+			}
+			filter.Labels = append(filter.Labels, name)
+		}
+		if len(filter.Labels) > maxLabelFilters {
+			return filter, sort, fmt.Errorf("labels: máximo %d etiquetas", maxLabelFilters)
+		}
+	}
+
+	switch match := c.DefaultQuery("label_match", models.LabelMatchAny); match {
+	case models.LabelMatchAny, models.LabelMatchAll:
+		filter.LabelMatch = match
+	default:
+		return filter, sort, fmt.Errorf("label_match inválido: debe ser any o all")
+	}
+
+	if field := c.Query("sort"); field != "" {
+		if err := validation.ValidateTaskSort(field); err != nil {
+			return filter, sort, err
+		}
+		sort.Field = field
+	}
+
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		sort.Descending = true
+	default:
+		return filter, sort, fmt.Errorf("order inválido: debe ser asc o desc")
+	}
+
+	return filter, sort, nil
+}
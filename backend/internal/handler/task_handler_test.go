@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		versions []int
+		wantErr  bool
+	}{
+		{name: "sin header", header: "", versions: nil},
+		{name: "comodín", header: "*", versions: nil},
+		{name: "ETag fuerte", header: `"3"`, versions: []int{3}},
+		{name: "ETag débil", header: `W/"3"`, versions: []int{3}},
+		{name: "lista", header: `"3", W/"4" ,"5"`, versions: []int{3, 4, 5}},
+		{name: "lista con comodín", header: `"3", *`, versions: nil},
+		{name: "sin comillas", header: "3", wantErr: true},
+		{name: "versión no numérica", header: `"abc"`, wantErr: true},
+		{name: "versión cero", header: `"0"`, wantErr: true},
+		{name: "entrada vacía en la lista", header: `"3",`, wantErr: true},
+		{name: "prefijo débil en minúscula", header: `w/"3"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("PUT", "/api/v1/tasks/task-1", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+
+			versions, err := ifMatchVersions(c)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("se esperaba error, se obtuvo %v", versions)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if !reflect.DeepEqual(versions, tt.versions) {
+				t.Errorf("versiones = %v, se esperaba %v", versions, tt.versions)
+			}
+		})
+	}
+}
+
+func TestUpdateAndPatchRejectTitlesAlike(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Los requests inválidos se rechazan antes de llegar al servicio
+	h := NewTaskHandler(nil, response.NewResponseWriter())
+	router := gin.New()
+	router.PUT("/tasks/:id", h.UpdateTask)
+	router.PATCH("/tasks/:id", h.PatchTask)
+
+	tests := []struct {
+		name  string
+		title string
+	}{
+		{name: "vacío", title: ""},
+		{name: "solo espacios", title: "   "},
+		{name: "101 caracteres", title: strings.Repeat("a", 101)},
+		{name: "101 caracteres multibyte", title: strings.Repeat("ñ", 101)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"title": tt.title})
+
+			var messages []string
+			for _, method := range []string{http.MethodPut, http.MethodPatch} {
+				req := httptest.NewRequest(method, "/tasks/6f1c2b8e-8a43-4c1e-9d6a-2f4b7c9e1a30", strings.NewReader(string(body)))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				if rec.Code != http.StatusBadRequest {
+					t.Fatalf("%s: status = %d, se esperaba 400", method, rec.Code)
+				}
+				var resp models.APIResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("%s: respuesta inválida: %v", method, err)
+				}
+				messages = append(messages, resp.Message)
+			}
+
+			if messages[0] != messages[1] {
+				t.Errorf("PUT respondió %q y PATCH %q; se esperaba el mismo error", messages[0], messages[1])
+			}
+		})
+	}
+
+	// 100 caracteres multibyte caben en la columna y se aceptan en ambos
+	title := strings.Repeat("ñ", 100)
+	if _, err := parseTaskPatch([]byte(`{"title":"` + title + `"}`)); err != nil {
+		t.Errorf("PATCH rechazó un título de 100 caracteres: %v", err)
+	}
+}
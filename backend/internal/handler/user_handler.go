@@ -5,8 +5,11 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
 	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
 	"github.com/taskflow/backend/internal/service"
+	"github.com/taskflow/backend/internal/utils/validation"
 )
 
 // UserHandler maneja los endpoints de usuarios
@@ -25,12 +28,13 @@ func NewUserHandler(userService *service.UserService, rw response.ResponseWriter
 
 // GetAllUsers godoc
 // @Summary Listar todos los usuarios
-// @Description Obtiene el listado de todos los usuarios registrados en el sistema
+// @Description Obtiene el listado de todos los usuarios registrados en el sistema (solo administradores)
 // @Tags Users
 // @Security Bearer
 // @Produce json
 // @Success 200 {object} models.APIResponse{data=[]models.User}
 // @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/v1/users [get]
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
@@ -56,3 +60,64 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 		"count": len(users),
 	})
 }
+
+// UpdateUserRole godoc
+// @Summary Cambiar rol de usuario
+// @Description Cambia el rol (admin, member, viewer) de un usuario (solo administradores) y revoca sus sesiones. Su access token vigente conserva el rol anterior hasta que expira (JWT_EXPIRATION_TIME)
+// @Tags Users
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Param request body models.UpdateUserRoleRequest true "Nuevo rol"
+// @Success 200 {object} models.APIResponse{data=models.User}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/users/{id}/role [patch]
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	targetID := c.Param("id")
+
+	if err := validation.ValidateUUID(targetID); err != nil {
+		h.responseWriter.ValidationError(c, fmt.Sprintf("ID inválido: %v", err))
+		return
+	}
+
+	var req models.UpdateUserRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	if err := validation.ValidateRole(req.Role); err != nil {
+		h.responseWriter.ValidationError(c, fmt.Sprintf("Rol: %v", err))
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.responseWriter.Unauthorized(c, "No autorizado")
+		return
+	}
+
+	user, err := h.userService.UpdateUserRole(c.Request.Context(), targetID, &req, userID.(string))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Rol actualizado exitosamente", user)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *UserHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/infrastructure/response"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/service"
+	"github.com/taskflow/backend/internal/utils/validation"
+)
+
+// WorkspaceHandler maneja los endpoints de workspaces
+type WorkspaceHandler struct {
+	workspaceService *service.WorkspaceService
+	responseWriter   response.ResponseWriter
+}
+
+// NewWorkspaceHandler crea una nueva instancia de WorkspaceHandler
+func NewWorkspaceHandler(workspaceService *service.WorkspaceService, rw response.ResponseWriter) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+		responseWriter:   rw,
+	}
+}
+
+// CreateWorkspace godoc
+// @Summary Crear workspace
+// @Description Crea un workspace cuyo dueño es el usuario autenticado
+// @Tags Workspaces
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body models.CreateWorkspaceRequest true "Datos del workspace"
+// @Success 201 {object} models.APIResponse{data=models.Workspace}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/workspaces [post]
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	var req models.CreateWorkspaceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	if err := validation.ValidateString(req.Name, 1, 100, "nombre"); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	workspace, err := h.workspaceService.CreateWorkspace(c.Request.Context(), &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Workspace creado exitosamente", workspace)
+}
+
+// ListWorkspaces godoc
+// @Summary Listar mis workspaces
+// @Description Obtiene los workspaces a los que pertenece el usuario autenticado
+// @Tags Workspaces
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=[]models.Workspace}
+// @Failure 401 {object} models.APIResponse
+// @Router /api/v1/workspaces [get]
+func (h *WorkspaceHandler) ListWorkspaces(c *gin.Context) {
+	workspaces, err := h.workspaceService.ListWorkspaces(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Workspaces obtenidos exitosamente", workspaces)
+}
+
+// GetWorkspace godoc
+// @Summary Obtener workspace
+// @Description Obtiene un workspace del que el usuario es miembro
+// @Tags Workspaces
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Success 200 {object} models.APIResponse{data=models.Workspace}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id} [get]
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	workspace, err := h.workspaceService.GetWorkspace(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Workspace obtenido exitosamente", workspace)
+}
+
+// UpdateWorkspace godoc
+// @Summary Actualizar workspace
+// @Description Actualiza nombre y descripción de un workspace (dueño o administradores)
+// @Tags Workspaces
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Param request body models.UpdateWorkspaceRequest true "Datos a actualizar"
+// @Success 200 {object} models.APIResponse{data=models.Workspace}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id} [put]
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	var req models.UpdateWorkspaceRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	if req.Name != nil {
+		if err := validation.ValidateString(*req.Name, 1, 100, "nombre"); err != nil {
+			h.responseWriter.ValidationError(c, err.Error())
+			return
+		}
+	}
+
+	workspace, err := h.workspaceService.UpdateWorkspace(c.Request.Context(), c.Param("id"), &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Workspace actualizado exitosamente", workspace)
+}
+
+// GetStatusTransitions godoc
+// @Summary Obtener transiciones de estado
+// @Description Obtiene las transiciones de estado de tareas vigentes en el workspace (personalizadas o por defecto)
+// @Tags Workspaces
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Success 200 {object} models.APIResponse{data=map[string][]string}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id}/status-transitions [get]
+func (h *WorkspaceHandler) GetStatusTransitions(c *gin.Context) {
+	transitions, err := h.workspaceService.GetStatusTransitions(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Transiciones de estado obtenidas exitosamente", transitions)
+}
+
+// UpdateStatusTransitions godoc
+// @Summary Configurar transiciones de estado
+// @Description Reemplaza las transiciones de estado de tareas permitidas en el workspace (dueño o administradores)
+// @Tags Workspaces
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Param request body models.StatusTransitionsRequest true "Transiciones permitidas por estado de origen"
+// @Success 200 {object} models.APIResponse{data=map[string][]string}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id}/status-transitions [put]
+func (h *WorkspaceHandler) UpdateStatusTransitions(c *gin.Context) {
+	var req models.StatusTransitionsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	transitions, err := h.workspaceService.UpdateStatusTransitions(c.Request.Context(), c.Param("id"), &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Transiciones de estado actualizadas exitosamente", transitions)
+}
+
+// ResetStatusTransitions godoc
+// @Summary Restablecer transiciones de estado
+// @Description Vuelve a las transiciones de estado por defecto (dueño o administradores)
+// @Tags Workspaces
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Success 200 {object} models.APIResponse{data=map[string][]string}
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id}/status-transitions [delete]
+func (h *WorkspaceHandler) ResetStatusTransitions(c *gin.Context) {
+	transitions, err := h.workspaceService.ResetStatusTransitions(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Transiciones de estado restablecidas exitosamente", transitions)
+}
+
+// DeleteWorkspace godoc
+// @Summary Eliminar workspace
+// @Description Elimina un workspace y todas sus tareas (solo el dueño)
+// @Tags Workspaces
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id} [delete]
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	if err := h.workspaceService.DeleteWorkspace(c.Request.Context(), c.Param("id"), c.GetString("user_id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Workspace eliminado exitosamente", nil)
+}
+
+// ListMembers godoc
+// @Summary Listar miembros del workspace
+// @Description Obtiene los miembros de un workspace del que el usuario es miembro
+// @Tags Workspaces
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Success 200 {object} models.APIResponse{data=[]models.WorkspaceMember}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id}/members [get]
+func (h *WorkspaceHandler) ListMembers(c *gin.Context) {
+	members, err := h.workspaceService.ListMembers(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Miembros obtenidos exitosamente", members)
+}
+
+// InviteMember godoc
+// @Summary Invitar miembro
+// @Description Agrega un usuario registrado al workspace por su email (dueño o administradores)
+// @Tags Workspaces
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Param request body models.InviteMemberRequest true "Usuario a invitar"
+// @Success 201 {object} models.APIResponse{data=models.WorkspaceMember}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id}/members [post]
+func (h *WorkspaceHandler) InviteMember(c *gin.Context) {
+	var req models.InviteMemberRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.responseWriter.ValidationError(c, err.Error())
+		return
+	}
+
+	member, err := h.workspaceService.InviteMember(c.Request.Context(), c.Param("id"), &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusCreated, "Miembro agregado exitosamente", member)
+}
+
+// RemoveMember godoc
+// @Summary Eliminar miembro
+// @Description Elimina a un miembro del workspace; cualquier miembro puede salir por sí mismo
+// @Tags Workspaces
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID del workspace"
+// @Param user_id path string true "ID del usuario"
+// @Success 200 {object} models.APIResponse
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse
+// @Router /api/v1/workspaces/{id}/members/{user_id} [delete]
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	targetUserID := c.Param("user_id")
+
+	if err := validation.ValidateUUID(targetUserID); err != nil {
+		h.responseWriter.ValidationError(c, fmt.Sprintf("ID de usuario inválido: %v", err))
+		return
+	}
+
+	if err := h.workspaceService.RemoveMember(c.Request.Context(), c.Param("id"), targetUserID, c.GetString("user_id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.responseWriter.Success(c, http.StatusOK, "Miembro eliminado exitosamente", nil)
+}
+
+// handleError maneja los errores de la aplicación
+func (h *WorkspaceHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		if appErr.Code == http.StatusForbidden {
+			h.responseWriter.Forbidden(c, appErr.Message)
+			return
+		}
+		h.responseWriter.Error(c, appErr.Code, appErr.Message)
+		return
+	}
+
+	h.responseWriter.InternalError(c, err.Error())
+}
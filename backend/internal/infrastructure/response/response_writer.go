@@ -16,6 +16,8 @@ type ResponseWriter interface {
 	Forbidden(c *gin.Context, message string)
 	NotFound(c *gin.Context, message string)
 	InternalError(c *gin.Context, message string)
+	PreconditionFailed(c *gin.Context, message string, data interface{})
+	Conflict(c *gin.Context, message string, data interface{})
 }
 
 // StandardResponseWriter implementación de ResponseWriter
@@ -72,3 +74,25 @@ func (w *StandardResponseWriter) NotFound(c *gin.Context, message string) {
 func (w *StandardResponseWriter) InternalError(c *gin.Context, message string) {
 	w.Error(c, http.StatusInternalServerError, message)
 }
+
+// PreconditionFailed envía error de precondición (412) con el estado actual del recurso
+func (w *StandardResponseWriter) PreconditionFailed(c *gin.Context, message string, data interface{}) {
+	response := models.APIResponse{
+		Data:       data,
+		StatusCode: http.StatusPreconditionFailed,
+		Message:    message,
+		Error:      true,
+	}
+	c.JSON(http.StatusPreconditionFailed, response)
+}
+
+// Conflict envía error de conflicto (409) con el detalle de lo que no se aplicó
+func (w *StandardResponseWriter) Conflict(c *gin.Context, message string, data interface{}) {
+	response := models.APIResponse{
+		Data:       data,
+		StatusCode: http.StatusConflict,
+		Message:    message,
+		Error:      true,
+	}
+	c.JSON(http.StatusConflict, response)
+}
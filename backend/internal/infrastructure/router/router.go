@@ -1,13 +1,17 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"github.com/taskflow/backend/internal/domain"
 	"github.com/taskflow/backend/internal/handler"
 	"github.com/taskflow/backend/internal/infrastructure/response"
 	"github.com/taskflow/backend/internal/middleware"
+	"github.com/taskflow/backend/internal/models"
 	"github.com/taskflow/backend/internal/utils/jwt"
 )
 
@@ -17,6 +21,19 @@ func Setup(
 	authHandler *handler.AuthHandler,
 	taskHandler *handler.TaskHandler,
 	userHandler *handler.UserHandler,
+	workspaceHandler *handler.WorkspaceHandler,
+	projectHandler *handler.ProjectHandler,
+	commentHandler *handler.CommentHandler,
+	checklistHandler *handler.ChecklistHandler,
+	dependencyHandler *handler.DependencyHandler,
+	recurrenceHandler *handler.RecurrenceHandler,
+	labelHandler *handler.LabelHandler,
+	attachmentHandler *handler.AttachmentHandler,
+	bulkTaskHandler *handler.BulkTaskHandler,
+	eventHandler *handler.EventHandler,
+	workspaceResolver middleware.WorkspaceResolver,
+	idempotencyStore domain.IdempotencyStore,
+	idempotencyTTL time.Duration,
 	jwtManager *jwt.Manager,
 ) {
 	// Middleware global
@@ -56,26 +73,130 @@ func Setup(
 		auth := protected.Group("/auth")
 		{
 			auth.GET("/profile", authHandler.GetProfile)
+			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/logout-all", authHandler.LogoutAll)
+		}
+
+		// Roles con permiso de escritura (los viewers solo leen)
+		writers := middleware.RequireRole(models.RoleAdmin, models.RoleMember)
+		adminOnly := middleware.RequireRole(models.RoleAdmin)
+
+		// Reintentos con Idempotency-Key en los POST de recursos (no en los de sesión)
+		idempotent := middleware.IdempotencyMiddleware(idempotencyStore, idempotencyTTL)
+
+		// Workspace routes
+		workspaces := protected.Group("/workspaces")
+		{
+			workspaces.GET("", workspaceHandler.ListWorkspaces)
+			workspaces.POST("", writers, idempotent, workspaceHandler.CreateWorkspace)
+			workspaces.GET("/:id", workspaceHandler.GetWorkspace)
+			workspaces.PUT("/:id", workspaceHandler.UpdateWorkspace)
+			workspaces.DELETE("/:id", workspaceHandler.DeleteWorkspace)
+			workspaces.GET("/:id/members", workspaceHandler.ListMembers)
+			workspaces.POST("/:id/members", idempotent, workspaceHandler.InviteMember)
+			workspaces.DELETE("/:id/members/:user_id", workspaceHandler.RemoveMember)
+			workspaces.GET("/:id/status-transitions", workspaceHandler.GetStatusTransitions)
+			workspaces.PUT("/:id/status-transitions", workspaceHandler.UpdateStatusTransitions)
+			workspaces.DELETE("/:id/status-transitions", workspaceHandler.ResetStatusTransitions)
+		}
+
+		// Rutas acotadas al workspace activo
+		inWorkspace := middleware.WorkspaceMiddleware(workspaceResolver)
+
+		// Project routes
+		projects := protected.Group("/projects")
+		projects.Use(inWorkspace)
+		{
+			projects.GET("", projectHandler.ListProjects)
+			projects.POST("", writers, idempotent, projectHandler.CreateProject)
+			projects.GET("/:id", projectHandler.GetProject)
+			projects.PUT("/:id", writers, projectHandler.UpdateProject)
+			projects.DELETE("/:id", writers, projectHandler.DeleteProject)
+			projects.GET("/:id/tasks", projectHandler.GetProjectTasks)
+			projects.GET("/:id/stats", projectHandler.GetProjectStats)
 		}
 
 		// Task routes
 		tasks := protected.Group("/tasks")
+		tasks.Use(inWorkspace)
 		{
-			tasks.POST("", taskHandler.CreateTask)
+			tasks.POST("", writers, idempotent, taskHandler.CreateTask)
+			tasks.POST("/bulk", writers, idempotent, bulkTaskHandler.BulkUpdate)
 			tasks.GET("", taskHandler.GetTasks)
 			tasks.GET("/my", taskHandler.GetMyTasks)
 			tasks.GET("/stats", taskHandler.GetTaskStats)
+			tasks.GET("/trash", taskHandler.GetTrash)
 			tasks.GET("/:id", taskHandler.GetTask)
-			tasks.PUT("/:id", taskHandler.UpdateTask)
-			tasks.DELETE("/:id", taskHandler.DeleteTask)
-			tasks.PATCH("/:id/status", taskHandler.UpdateTaskStatus)
-			tasks.POST("/:id/assign", taskHandler.AssignTask)
+			tasks.PUT("/:id", writers, taskHandler.UpdateTask)
+			tasks.PATCH("/:id", writers, taskHandler.PatchTask)
+			tasks.DELETE("/:id", writers, taskHandler.DeleteTask)
+			tasks.POST("/:id/restore", writers, idempotent, taskHandler.RestoreTask)
+			tasks.PATCH("/:id/status", writers, taskHandler.UpdateTaskStatus)
+			tasks.POST("/:id/assign", writers, idempotent, taskHandler.AssignTask)
+			tasks.POST("/:id/assign/me", writers, idempotent, taskHandler.AssignToMe)
+			tasks.DELETE("/:id/assign/me", writers, taskHandler.UnassignMe)
+			tasks.POST("/:id/assignees", writers, idempotent, taskHandler.AddAssignee)
+			tasks.DELETE("/:id/assignees/:user_id", writers, taskHandler.RemoveAssignee)
+			tasks.POST("/:id/watchers", idempotent, taskHandler.AddWatcher)
+			tasks.DELETE("/:id/watchers/:user_id", taskHandler.RemoveWatcher)
+			tasks.GET("/:id/activity", taskHandler.GetTaskActivity)
+			tasks.GET("/:id/comments", commentHandler.ListComments)
+			tasks.POST("/:id/comments", writers, idempotent, commentHandler.CreateComment)
+			tasks.PUT("/:id/parent", writers, taskHandler.SetParentTask)
+			tasks.GET("/:id/subtasks", taskHandler.GetSubtasks)
+			tasks.GET("/:id/checklist", checklistHandler.ListItems)
+			tasks.POST("/:id/checklist", writers, idempotent, checklistHandler.CreateItem)
+			tasks.PATCH("/:id/checklist/:item_id", writers, checklistHandler.UpdateItem)
+			tasks.DELETE("/:id/checklist/:item_id", writers, checklistHandler.DeleteItem)
+			tasks.GET("/:id/dependencies", dependencyHandler.GetDependencies)
+			tasks.POST("/:id/dependencies", writers, idempotent, dependencyHandler.AddDependency)
+			tasks.DELETE("/:id/dependencies/:task_id", writers, dependencyHandler.RemoveDependency)
+			tasks.GET("/:id/recurrence", recurrenceHandler.GetRecurrence)
+			tasks.PUT("/:id/recurrence", writers, recurrenceHandler.SetRecurrence)
+			tasks.DELETE("/:id/recurrence", writers, recurrenceHandler.DeleteRecurrence)
+			tasks.POST("/:id/labels", writers, idempotent, labelHandler.AttachLabel)
+			tasks.DELETE("/:id/labels/:label_id", writers, labelHandler.DetachLabel)
+
+			// Adjuntos
+			tasks.GET("/:id/attachments", attachmentHandler.ListAttachments)
+			tasks.POST("/:id/attachments", writers, idempotent, attachmentHandler.UploadAttachment)
+			tasks.GET("/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+			tasks.DELETE("/:id/attachments/:attachment_id", writers, attachmentHandler.DeleteAttachment)
+		}
+
+		// Eventos en tiempo real
+		events := protected.Group("/events")
+		events.Use(inWorkspace)
+		{
+			events.GET("", eventHandler.StreamEvents)
+			events.GET("/ws", eventHandler.StreamEventsWebSocket)
+		}
+
+		// Label routes
+		labels := protected.Group("/labels")
+		labels.Use(inWorkspace)
+		{
+			labels.GET("", labelHandler.ListLabels)
+			labels.POST("", writers, idempotent, labelHandler.CreateLabel)
+			labels.PATCH("/:id", writers, labelHandler.UpdateLabel)
+			labels.DELETE("/:id", writers, labelHandler.DeleteLabel)
+		}
+
+		// Comment routes
+		comments := protected.Group("/comments")
+		comments.Use(inWorkspace)
+		{
+			comments.PATCH("/:id", writers, commentHandler.UpdateComment)
+			comments.DELETE("/:id", writers, commentHandler.DeleteComment)
+			comments.GET("/:id/history", commentHandler.GetCommentHistory)
 		}
 
 		// User routes
 		users := protected.Group("/users")
+		users.Use(adminOnly)
 		{
 			users.GET("", userHandler.GetAllUsers)
+			users.PATCH("/:id/role", userHandler.UpdateUserRole)
 		}
 	}
 }
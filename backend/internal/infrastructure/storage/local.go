@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/taskflow/backend/internal/domain"
+)
+
+// LocalStore implementa domain.BlobStore sobre un directorio del sistema de archivos
+type LocalStore struct {
+	root string
+}
+
+// NewLocalStore crea el almacenamiento local en root, creando el directorio si no existe
+func NewLocalStore(root string) (domain.BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("error al crear directorio de almacenamiento: %w", err)
+	}
+	return &LocalStore{root: root}, nil
+}
+
+// path convierte la clave en una ruta dentro de root. Limpiar la clave como ruta
+// absoluta descarta cualquier ".." que intente salir del directorio
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(filepath.Clean("/"+key)))
+}
+
+// Put escribe el contenido en un archivo temporal y lo renombra para que un
+// archivo a medio escribir nunca sea visible
+func (s *LocalStore) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	target := s.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("error al crear directorio de almacenamiento: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("error al crear archivo temporal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error al escribir archivo: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error al escribir archivo: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("error al guardar archivo: %w", err)
+	}
+
+	return nil
+}
+
+// Get abre el archivo guardado bajo la clave
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("error al abrir archivo: %w", err)
+	}
+
+	return file, nil
+}
+
+// Delete elimina el archivo guardado bajo la clave
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error al eliminar archivo: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taskflow/backend/internal/domain"
+)
+
+const (
+	// emptyPayloadHash SHA-256 de un body vacío, usado al firmar GET y DELETE
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// unsignedPayload indica a S3 que el body no forma parte de la firma, para poder
+	// enviarlo en streaming sin leerlo antes
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Config configuración de un almacenamiento compatible con S3 (AWS, MinIO)
+type S3Config struct {
+	Endpoint  string // por ejemplo http://localhost:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Store implementa domain.BlobStore contra la API REST de S3 usando direcciones
+// de tipo path (endpoint/bucket/clave) y firmas AWS Signature V4
+type S3Store struct {
+	endpoint *url.URL
+	config   S3Config
+	client   *http.Client
+}
+
+// NewS3Store crea el almacenamiento S3 con la configuración indicada
+func NewS3Store(config S3Config) (domain.BlobStore, error) {
+	endpoint, err := url.Parse(strings.TrimRight(config.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("endpoint S3 inválido: %q", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("falta el bucket S3")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	return &S3Store{
+		endpoint: endpoint,
+		config:   config,
+		client:   &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// Put sube el contenido como objeto. El body se envía en streaming con
+// UNSIGNED-PAYLOAD; size debe ser su tamaño exacto
+func (s *S3Store) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, content, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al subir archivo a S3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3Error("subir", resp)
+	}
+
+	return nil
+}
+
+// Get descarga el objeto; el llamador debe cerrar el contenido
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al descargar archivo de S3: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, domain.ErrBlobNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3Error("descargar", resp)
+	}
+
+	return resp.Body, nil
+}
+
+// Delete elimina el objeto; S3 responde 204 aunque no exista
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al eliminar archivo de S3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3Error("eliminar", resp)
+	}
+
+	return nil
+}
+
+// newRequest construye y firma un request sobre el objeto indicado
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader, payloadHash string) (*http.Request, error) {
+	target := *s.endpoint
+	target.Path = s.endpoint.Path + "/" + s.config.Bucket + "/" + key
+	target.RawPath = s.endpoint.EscapedPath() + "/" + uriEncode(s.config.Bucket, false) + "/" + uriEncode(key, true)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request S3: %w", err)
+	}
+
+	s.sign(req, payloadHash, time.Now().UTC())
+	return req, nil
+}
+
+// sign agrega los headers de AWS Signature V4 firmando host, x-amz-content-sha256 y x-amz-date
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretKey), date)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+// hmacSHA256 calcula HMAC-SHA256 de data con la clave indicada
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// uriEncode codifica según las reglas de Signature V4: solo se conservan los
+// caracteres no reservados y, si keepSlash, las barras
+func uriEncode(value string, keepSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(value) {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && keepSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteString("%" + strings.ToUpper(strconv.FormatInt(int64(c)|0x100, 16)[1:]))
+		}
+	}
+	return b.String()
+}
+
+// s3Error construye un error con el código HTTP y el inicio del mensaje de S3
+func s3Error(action string, resp *http.Response) error {
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("error al %s archivo en S3: %s: %s", action, resp.Status, strings.TrimSpace(string(detail)))
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/utils/validation"
+)
+
+const (
+	// IdempotencyKeyHeader header con el que el cliente identifica un request reintentable
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader header que marca una respuesta repetida desde el almacén
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyMiddleware hace idempotentes los POST que traen Idempotency-Key: la primera
+// respuesta se guarda por usuario durante ttl y los reintentos la reciben sin volver a
+// ejecutar el handler. La misma clave con otro request responde 422. Debe usarse después
+// de AuthMiddleware y solo en los POST que crean o modifican recursos
+func IdempotencyMiddleware(store domain.IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(c, http.StatusBadRequest, "Header "+IdempotencyKeyHeader+" inválido")
+			return
+		}
+
+		// El cuerpo se lee completo para la huella: se acota como en el resto de la API
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, validation.MaxRequestBodySize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if stderrors.As(err, &maxBytesErr) {
+				abortWithError(c, http.StatusRequestEntityTooLarge, "Request body demasiado grande (máximo 10MB)")
+				return
+			}
+			abortWithError(c, http.StatusBadRequest, "No se pudo leer el cuerpo del request")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := c.GetString("user_id")
+		fingerprint := requestFingerprint(c, body)
+
+		record, err := store.Reserve(c.Request.Context(), userID, key, fingerprint, ttl)
+		if err != nil {
+			log.Printf("🔴 IdempotencyMiddleware - Error reservando clave: %v\n", err)
+			if appErr, ok := err.(*errors.AppError); ok {
+				abortWithError(c, appErr.Code, appErr.Message)
+				return
+			}
+			abortWithError(c, http.StatusInternalServerError, "Error interno del servidor")
+			return
+		}
+
+		if record != nil {
+			switch {
+			case record.Fingerprint != fingerprint:
+				abortWithError(c, http.StatusUnprocessableEntity, "El "+IdempotencyKeyHeader+" ya se usó con otro request")
+			case record.StatusCode == 0:
+				abortWithError(c, http.StatusConflict, "Hay un request en curso con el mismo "+IdempotencyKeyHeader)
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(record.StatusCode, record.ContentType, record.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		// La respuesta se guarda aunque el cliente haya cortado la conexión: es justo
+		// el caso en que va a reintentar
+		ctx := context.WithoutCancel(c.Request.Context())
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := store.Release(ctx, userID, key); err != nil {
+				log.Printf("🔴 IdempotencyMiddleware - Error liberando clave: %v\n", err)
+			}
+		}()
+
+		c.Next()
+
+		// Los errores del servidor no se guardan para que el reintento vuelva a ejecutarse
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		if err := store.Complete(ctx, userID, key, recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			log.Printf("🔴 IdempotencyMiddleware - Error guardando respuesta: %v\n", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// requestFingerprint identifica el request que se hizo con una clave: método, ruta,
+// workspace y cuerpo
+func requestFingerprint(c *gin.Context, body []byte) string {
+	hash := sha256.New()
+	for _, part := range []string{c.Request.Method, c.Request.URL.RequestURI(), c.GetHeader(WorkspaceHeader)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder copia el cuerpo de la respuesta mientras se envía al cliente
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
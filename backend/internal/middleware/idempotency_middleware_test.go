@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/repository/memory"
+	"github.com/taskflow/backend/internal/utils/validation"
+)
+
+// idempotencyRouter arma un router con IdempotencyMiddleware delante de handler y un
+// usuario autenticado fijo
+func idempotencyRouter(handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/tasks", func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+	}, IdempotencyMiddleware(memory.NewIdempotencyStore(), time.Hour), handler)
+	return router
+}
+
+// postWithKey envía un POST /tasks con Idempotency-Key
+func postWithKey(router http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyMiddlewareReplaysResponse(t *testing.T) {
+	calls := 0
+	router := idempotencyRouter(func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	first := postWithKey(router, "clave-1", `{"title":"a"}`)
+	second := postWithKey(router, "clave-1", `{"title":"a"}`)
+
+	if calls != 1 {
+		t.Fatalf("el handler se ejecutó %d veces, se esperaba 1", calls)
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("la primera respuesta no debe marcarse como repetida")
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("reintento = %d %s, se esperaba la primera respuesta %d %s", second.Code, second.Body, first.Code, first.Body)
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("%s = %q, se esperaba true", IdempotentReplayedHeader, second.Header().Get(IdempotentReplayedHeader))
+	}
+	if got := second.Header().Get("Content-Type"); got != first.Header().Get("Content-Type") {
+		t.Errorf("Content-Type repetido = %q, se esperaba %q", got, first.Header().Get("Content-Type"))
+	}
+}
+
+func TestIdempotencyMiddlewareRejectsDifferentBody(t *testing.T) {
+	calls := 0
+	router := idempotencyRouter(func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	postWithKey(router, "clave-1", `{"title":"a"}`)
+	rec := postWithKey(router, "clave-1", `{"title":"b"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, se esperaba 422", rec.Code)
+	}
+	if calls != 1 {
+		t.Errorf("el handler se ejecutó %d veces, se esperaba 1", calls)
+	}
+}
+
+func TestIdempotencyMiddlewareRejectsConcurrentRequest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := idempotencyRouter(func(c *gin.Context) {
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postWithKey(router, "clave-1", `{"title":"a"}`)
+	}()
+	<-started
+
+	rec := postWithKey(router, "clave-1", `{"title":"a"}`)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status mientras el primero sigue en curso = %d, se esperaba 409", rec.Code)
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("status del primer request = %d, se esperaba 201", first.Code)
+	}
+
+	// Terminado el primero, el reintento recibe su respuesta
+	if rec := postWithKey(router, "clave-1", `{"title":"a"}`); rec.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("el reintento tras terminar el primero debe repetir su respuesta, se obtuvo %d", rec.Code)
+	}
+}
+
+func TestIdempotencyMiddlewareReleasesKeyOnServerError(t *testing.T) {
+	calls := 0
+	router := idempotencyRouter(func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			c.JSON(http.StatusInternalServerError, gin.H{})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	if rec := postWithKey(router, "clave-1", `{"title":"a"}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, se esperaba 500", rec.Code)
+	}
+
+	rec := postWithKey(router, "clave-1", `{"title":"a"}`)
+	if rec.Code != http.StatusCreated || rec.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("reintento = %d (replayed=%q), se esperaba que volviera a ejecutarse", rec.Code, rec.Header().Get(IdempotentReplayedHeader))
+	}
+	if calls != 2 {
+		t.Errorf("el handler se ejecutó %d veces, se esperaban 2", calls)
+	}
+}
+
+func TestIdempotencyMiddlewareRejectsOversizedBody(t *testing.T) {
+	calls := 0
+	router := idempotencyRouter(func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	rec := postWithKey(router, "clave-1", strings.Repeat("a", validation.MaxRequestBodySize+1))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, se esperaba 413", rec.Code)
+	}
+	if calls != 0 {
+		t.Errorf("el handler se ejecutó %d veces, se esperaba 0", calls)
+	}
+}
@@ -41,8 +41,8 @@ func AuthMiddleware(jwtManager *jwt.Manager) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		// Validar el token
-		claims, err := jwtManager.ValidateToken(tokenString)
+		// Validar el token (solo se aceptan access tokens)
+		claims, err := jwtManager.ValidateAccessToken(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, models.APIResponse{
 				Data:       nil,
@@ -57,6 +57,31 @@ func AuthMiddleware(jwtManager *jwt.Manager) gin.HandlerFunc {
 		// Guardar datos en contexto
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("role", claims.Role)
+
+		c.Next()
+	}
+}
+
+// RequireRole middleware que solo permite el acceso a usuarios con alguno de los roles indicados.
+// Debe usarse después de AuthMiddleware
+func RequireRole(roles ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(c *gin.Context) {
+		if !allowed[c.GetString("role")] {
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Data:       nil,
+				StatusCode: http.StatusForbidden,
+				Message:    "No tienes permiso para acceder a este recurso",
+				Error:      true,
+			})
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
@@ -72,7 +97,8 @@ func CORSMiddleware() gin.HandlerFunc {
 
 		c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "false")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Workspace-ID, If-Match, Idempotency-Key, Last-Event-ID, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, Idempotent-Replayed")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400")
 
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/utils/jwt"
+)
+
+func TestAuthMiddlewareTokenType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := jwt.NewManager("secreto-de-prueba", 3600, 604800)
+
+	access, err := m.GenerateToken("user-1", "ana@example.com", "member")
+	if err != nil {
+		t.Fatalf("error al generar access token: %v", err)
+	}
+	refresh, err := m.GenerateRefreshToken("user-1", "ana@example.com", "token-1")
+	if err != nil {
+		t.Fatalf("error al generar refresh token: %v", err)
+	}
+
+	router := gin.New()
+	router.GET("/protegido", AuthMiddleware(m), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{name: "access token", token: access, want: http.StatusOK},
+		{name: "refresh token", token: refresh, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/protegido", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, se esperaba %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -23,7 +23,7 @@ func ValidationMiddleware(rw response.ResponseWriter) gin.HandlerFunc {
 		log.Printf("📝 ValidationMiddleware - Validando request: %s %s\n", c.Request.Method, c.Request.URL.Path)
 
 		// Validar tamaño del body
-		if c.Request.ContentLength > 10*1024*1024 { // 10MB máximo
+		if c.Request.ContentLength > validation.MaxRequestBodySize { // 10MB máximo
 			log.Printf("🔴 ValidationMiddleware - Body muy grande: %d bytes\n", c.Request.ContentLength)
 			rw.ValidationError(c, "Request body demasiado grande (máximo 10MB)")
 			c.Abort()
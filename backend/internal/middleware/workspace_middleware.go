@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/utils/validation"
+)
+
+// WorkspaceHeader header con el que el cliente elige el workspace activo
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceResolver resuelve la membresía del usuario en el workspace solicitado
+type WorkspaceResolver interface {
+	ResolveWorkspace(ctx context.Context, userID, workspaceID string) (*models.WorkspaceMember, error)
+}
+
+// WorkspaceMiddleware resuelve el workspace activo del request a partir del header
+// X-Workspace-ID (o el workspace por defecto del usuario) y lo guarda en el contexto.
+// Debe usarse después de AuthMiddleware
+func WorkspaceMiddleware(resolver WorkspaceResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		workspaceID := c.GetHeader(WorkspaceHeader)
+		if workspaceID != "" {
+			if err := validation.ValidateUUID(workspaceID); err != nil {
+				abortWithError(c, http.StatusBadRequest, "Header "+WorkspaceHeader+" inválido")
+				return
+			}
+		}
+
+		member, err := resolver.ResolveWorkspace(c.Request.Context(), c.GetString("user_id"), workspaceID)
+		if err != nil {
+			log.Printf("🔴 WorkspaceMiddleware - Error resolviendo workspace: %v\n", err)
+			if appErr, ok := err.(*errors.AppError); ok {
+				abortWithError(c, appErr.Code, appErr.Message)
+				return
+			}
+			abortWithError(c, http.StatusInternalServerError, "Error interno del servidor")
+			return
+		}
+
+		c.Set("workspace_id", member.WorkspaceID)
+		c.Set("workspace_role", member.Role)
+
+		c.Next()
+	}
+}
+
+// abortWithError responde con el formato estándar de error y detiene la cadena de handlers
+func abortWithError(c *gin.Context, statusCode int, message string) {
+	c.JSON(statusCode, models.APIResponse{
+		Data:       nil,
+		StatusCode: statusCode,
+		Message:    message,
+		Error:      true,
+	})
+	c.Abort()
+}
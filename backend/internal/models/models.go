@@ -1,32 +1,396 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
 // ...existing code...
 
+// Roles de usuario soportados
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+// Roles de miembro dentro de un workspace
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+// Estados de tarea soportados
+const (
+	TaskStatusPending    = "pending"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusCompleted  = "completed"
+	TaskStatusCancelled  = "cancelled"
+)
+
 // User representa un usuario del sistema
 type User struct {
 	ID        string    `json:"id"`
 	Email     string    `json:"email"`
 	Name      string    `json:"name"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // Task representa una tarea del sistema
 type Task struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      string     `json:"status"`
-	Priority    string     `json:"priority"`
-	DueDate     *time.Time `json:"due_date"`
-	CreatedBy   string     `json:"created_by"`
-	AssignedTo  *string    `json:"assigned_to"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID           string     `json:"id"`
+	WorkspaceID  string     `json:"workspace_id"`
+	ProjectID    *string    `json:"project_id"`
+	ParentID     *string    `json:"parent_task_id"`
+	RecurrenceID *string    `json:"recurrence_id"` // regla de repetición de la que forma parte
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	Priority     string     `json:"priority"`
+	DueDate      *time.Time `json:"due_date"`
+	CreatedBy    string     `json:"created_by"`
+	AssignedTo   *string    `json:"assigned_to"` // responsable principal
+	AssignedBy   *string    `json:"assigned_by"` // quién fijó al responsable principal
+	AssignedAt   *time.Time `json:"assigned_at"`
+	StartedAt    *time.Time `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"` // solo en la papelera
+	DeletedBy    *string    `json:"deleted_by,omitempty"`
+	Version      int        `json:"version"` // se incrementa en cada escritura; se expone como ETag
+
+	Labels    []Label  `json:"labels"`
+	Assignees []string `json:"assignees"` // IDs de todos los responsables, incluido el principal
+	Watchers  []string `json:"watchers"`  // IDs de los usuarios que siguen la tarea
+
+	// Resumen de subtareas y checklist calculado al leer la tarea
+	SubtaskCount          int `json:"subtask_count"`
+	CompletedSubtaskCount int `json:"completed_subtask_count"`
+	ChecklistProgress     int `json:"checklist_progress"` // porcentaje 0-100 de ítems completados
+}
+
+// ChecklistItem representa un paso de la checklist de una tarea
+type ChecklistItem struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Text      string    `json:"text"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Label representa una etiqueta para clasificar tareas. Las etiquetas con UserID
+// son personales: solo su dueño las ve en el catálogo y puede aplicarlas
+type Label struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      *string   `json:"user_id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	CreatedBy   *string   `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Attachment representa un archivo adjunto a una tarea. El contenido se guarda en
+// el BlobStore bajo StorageKey
+type Attachment struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"` // SHA-256 en hexadecimal
+	StorageKey  string    `json:"-"`
+	UploadedBy  *string   `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Frecuencias de repetición de tareas
+const (
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// TaskRecurrence representa la regla de repetición de una tarea. La tarea de la
+// regla es la plantilla de las ocurrencias; cada ocurrencia vence según la regla
+// contando desde StartsAt
+type TaskRecurrence struct {
+	ID             string     `json:"id"`
+	WorkspaceID    string     `json:"workspace_id"`
+	TaskID         string     `json:"task_id"`
+	Frequency      string     `json:"frequency"`
+	Interval       int        `json:"interval"`
+	Weekdays       []int      `json:"weekdays,omitempty"` // 0 = domingo ... 6 = sábado
+	MonthDay       *int       `json:"month_day,omitempty"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	MaxOccurrences *int       `json:"max_occurrences"`
+	Occurrences    int        `json:"occurrences"`
+	LastTaskID     *string    `json:"last_task_id"`
+	LastDueAt      time.Time  `json:"last_due_at"`
+	NextDueAt      *time.Time `json:"next_due_at"` // calculada; nil si la serie terminó
+	Active         bool       `json:"active"`
+	CreatedBy      *string    `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Tipos de dependencia vistos desde la tarea indicada en la ruta
+const (
+	DependencyBlocks    = "blocks"
+	DependencyBlockedBy = "blocked_by"
+)
+
+// TaskDependencyEdge representa que blocker_task_id bloquea a blocked_task_id
+type TaskDependencyEdge struct {
+	BlockerID string `json:"blocker_task_id"`
+	BlockedID string `json:"blocked_task_id"`
+}
+
+// TaskDependencyNode resume una tarea del grafo de dependencias
+type TaskDependencyNode struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"`
+	Priority   string     `json:"priority"`
+	AssignedTo *string    `json:"assigned_to"`
+	DueDate    *time.Time `json:"due_date"`
+	Depth      int        `json:"depth"` // 1 = dependencia directa
+}
+
+// TaskDependencyGraph grafo de dependencias alrededor de una tarea
+type TaskDependencyGraph struct {
+	TaskID     string               `json:"task_id"`
+	Blocked    bool                 `json:"blocked"`    // tiene bloqueadores directos abiertos
+	Upstream   []TaskDependencyNode `json:"upstream"`   // tareas por las que espera
+	Downstream []TaskDependencyNode `json:"downstream"` // tareas que esperan por ella
+	Edges      []TaskDependencyEdge `json:"edges"`
+}
+
+// Workspace representa un espacio de trabajo que agrupa usuarios y tareas
+type Workspace struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	OwnerID     string    `json:"owner_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WorkspaceMember representa la pertenencia de un usuario a un workspace
+type WorkspaceMember struct {
+	WorkspaceID string    `json:"workspace_id"`
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Project representa una lista de tareas dentro de un workspace
+type Project struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Archived    bool      `json:"archived"`
+	OwnerID     string    `json:"owner_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Campos especiales de TaskEvent para la creación, eliminación y restauración de la tarea
+const (
+	TaskEventCreated  = "created"
+	TaskEventDeleted  = "deleted"
+	TaskEventRestored = "restored"
+)
+
+// TaskEvent representa un cambio registrado en el historial de una tarea
+type TaskEvent struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	ActorID   string    `json:"actor_id"`
+	ActorName string    `json:"actor_name"`
+	Field     string    `json:"field"`
+	OldValue  *string   `json:"old_value"`
+	NewValue  *string   `json:"new_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Tipos de TaskChange
+const (
+	TaskChangeCreated       = "task.created"
+	TaskChangeUpdated       = "task.updated"
+	TaskChangeStatusChanged = "task.status_changed"
+	TaskChangeAssigned      = "task.assigned"
+	TaskChangeDeleted       = "task.deleted"
+	TaskChangeRestored      = "task.restored"
+	// TaskChangeRevoked aviso a quien dejó de ver la tarea por el cambio. Solo lleva
+	// id, type y task_id: el cliente descarta la tarea sin conocer su nuevo estado
+	TaskChangeRevoked = "task.revoked"
+)
+
+// TaskChange representa un evento en tiempo real sobre una tarea. El ID es creciente
+// y es el que el cliente envía en Last-Event-ID para retomar el stream
+type TaskChange struct {
+	ID          int64     `json:"id"`
+	Type        string    `json:"type"`
+	WorkspaceID string    `json:"workspace_id"`
+	TaskID      string    `json:"task_id"`
+	ActorID     string    `json:"actor_id"`
+	Task        *Task     `json:"task"` // estado de la tarea tras el cambio
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Usuarios que veían la tarea antes del cambio (creador, responsables y seguidores);
+	// quienes dejan de verla también reciben el evento
+	PreviousViewers []string `json:"-"`
+}
+
+// MarshalJSON serializa el evento. Los de TaskChangeRevoked solo llevan id, type y
+// task_id
+func (c TaskChange) MarshalJSON() ([]byte, error) {
+	if c.Type == TaskChangeRevoked {
+		return json.Marshal(struct {
+			ID     int64  `json:"id"`
+			Type   string `json:"type"`
+			TaskID string `json:"task_id"`
+		}{ID: c.ID, Type: c.Type, TaskID: c.TaskID})
+	}
+
+	type taskChange TaskChange
+	return json.Marshal(taskChange(c))
+}
+
+// Comment representa un comentario sobre una tarea. Las respuestas se agrupan
+// bajo su comentario raíz en Replies
+type Comment struct {
+	ID         string           `json:"id"`
+	TaskID     string           `json:"task_id"`
+	ParentID   *string          `json:"parent_id"`
+	AuthorID   string           `json:"author_id"`
+	AuthorName string           `json:"author_name"`
+	Body       string           `json:"body"`
+	Mentions   []CommentMention `json:"mentions"`
+	Replies    []Comment        `json:"replies,omitempty"`
+	EditedAt   *time.Time       `json:"edited_at"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// CommentMention representa un usuario mencionado con @email en un comentario
+type CommentMention struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// CommentEdit representa una versión anterior de un comentario editado
+type CommentEdit struct {
+	ID           string    `json:"id"`
+	CommentID    string    `json:"comment_id"`
+	PreviousBody string    `json:"previous_body"`
+	EditedBy     string    `json:"edited_by"`
+	EditedAt     time.Time `json:"edited_at"`
+}
+
+// TaskFilter criterios de filtrado para listar tareas y calcular estadísticas
+type TaskFilter struct {
+	UserID     string // tareas creadas por este usuario o de las que es responsable
+	Status     string
+	ProjectID  string
+	ParentID   string   // subtareas directas de esta tarea
+	IDs        []string // solo las tareas con estos IDs
+	Priority   string
+	AssignedTo string // cualquiera de los responsables
+	CreatedBy  string
+	Unassigned bool
+	Overdue    bool // vencidas y aún abiertas
+	DueFrom    *time.Time
+	DueTo      *time.Time
+	Query      string   // búsqueda de texto en título y descripción
+	Labels     []string // nombres de etiquetas (sin distinguir mayúsculas)
+	LabelMatch string   // any (por defecto) o all
+	LabelOwner string   // usuario cuyas etiquetas personales se cargan en las tareas y cuentan en Labels, además de las del workspace
+	Trashed    bool     // tareas en la papelera en lugar de las activas
+}
+
+// Modos de coincidencia del filtro de etiquetas
+const (
+	LabelMatchAny = "any"
+	LabelMatchAll = "all"
+)
+
+// Campos por los que se pueden ordenar las tareas
+const (
+	TaskSortCreatedAt = "created_at"
+	TaskSortDueDate   = "due_date"
+	TaskSortPriority  = "priority"
+	TaskSortUpdatedAt = "updated_at"
+	TaskSortTitle     = "title"
+	TaskSortDeletedAt = "deleted_at" // solo para la papelera; no se acepta en los listados
+)
+
+// TaskSort orden de un listado de tareas. Field vacío usa el orden por defecto
+type TaskSort struct {
+	Field      string
+	Descending bool
+}
+
+// TaskCursor posición de la última tarea entregada en la paginación por cursor:
+// el orden usado, el valor de la clave de orden (nil si es NULL) y el ID que desempata
+type TaskCursor struct {
+	Sort       string  `json:"s"`
+	Descending bool    `json:"d"`
+	Value      *string `json:"v"`
+	ID         string  `json:"id"`
+}
+
+// Actor representa al usuario autenticado que ejecuta una operación
+// dentro del workspace resuelto para el request
+type Actor struct {
+	UserID        string
+	Role          string
+	WorkspaceID   string
+	WorkspaceRole string
+}
+
+// IsAdmin indica si el actor tiene rol de administrador
+func (a *Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// IsWorkspaceAdmin indica si el actor administra el workspace actual
+func (a *Actor) IsWorkspaceAdmin() bool {
+	return a.WorkspaceRole == WorkspaceRoleOwner || a.WorkspaceRole == WorkspaceRoleAdmin
+}
+
+// RefreshToken representa un refresh token emitido y almacenado en el servidor
+type RefreshToken struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	FamilyID   string     `json:"family_id"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	ReplacedBy *string    `json:"replaced_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IdempotencyRecord respuesta guardada para un Idempotency-Key. StatusCode es 0
+// mientras el request original sigue en curso
+type IdempotencyRecord struct {
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
 }
 
 // TaskStats contiene estadísticas de tareas de un usuario
@@ -70,39 +434,242 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+// UpdateUserRoleRequest modelo para cambiar el rol de un usuario
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member viewer"`
+}
+
+// CreateWorkspaceRequest modelo para crear un workspace
+type CreateWorkspaceRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// UpdateWorkspaceRequest modelo para actualizar un workspace
+type UpdateWorkspaceRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=500"`
+}
+
+// StatusTransitionsRequest modelo para configurar las transiciones de estado de un workspace.
+// Cada clave es un estado de origen y su valor los estados a los que puede pasar
+type StatusTransitionsRequest struct {
+	Transitions map[string][]string `json:"transitions" binding:"required"`
+}
+
+// InviteMemberRequest modelo para invitar a un usuario a un workspace
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=admin member"`
+}
+
+// CreateCommentRequest modelo para comentar una tarea o responder un comentario
+type CreateCommentRequest struct {
+	Body     string  `json:"body" binding:"required,max=2000"`
+	ParentID *string `json:"parent_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// UpdateCommentRequest modelo para editar un comentario
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+// TaskEventsListResponse respuesta con el historial paginado de una tarea
+type TaskEventsListResponse struct {
+	Events     []TaskEvent `json:"events"`
+	Total      int         `json:"total"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"page_size"`
+	TotalPages int         `json:"total_pages"`
+}
+
+// CommentsListResponse respuesta con lista paginada de comentarios raíz
+type CommentsListResponse struct {
+	Comments   []Comment `json:"comments"`
+	Total      int       `json:"total"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	TotalPages int       `json:"total_pages"`
+}
+
+// CreateProjectRequest modelo para crear un proyecto
+type CreateProjectRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// UpdateProjectRequest modelo para actualizar o archivar un proyecto
+type UpdateProjectRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=500"`
+	Archived    *bool   `json:"archived,omitempty"`
+}
+
+// LogoutRequest modelo para cerrar la sesión actual
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
 // CreateTaskRequest modelo para crear tarea
 type CreateTaskRequest struct {
 	Title       string  `json:"title" binding:"required,max=100"`
 	Description string  `json:"description" binding:"max=500"`
 	Priority    string  `json:"priority" binding:"required,oneof=low medium high urgent"`
 	DueDate     *string `json:"due_date,omitempty"` // Fecha como string plano
+	ProjectID   *string `json:"project_id,omitempty" binding:"omitempty,uuid"`
+	ParentID    *string `json:"parent_task_id,omitempty" binding:"omitempty,uuid"`
 }
 
-// UpdateTaskRequest modelo para actualizar tarea
+// UpdateTaskRequest modelo para actualizar tarea. El título y la descripción se
+// validan en el handler con las mismas funciones que PATCH
 type UpdateTaskRequest struct {
-	Title       *string `json:"title,omitempty" binding:"omitempty,max=100"`
-	Description *string `json:"description,omitempty" binding:"omitempty,max=500"`
+	Title       *string `json:"title,omitempty" maxLength:"100"`
+	Description *string `json:"description,omitempty" maxLength:"500"`
 	Priority    *string `json:"priority,omitempty" binding:"omitempty,oneof=low medium high urgent"`
 	DueDate     *string `json:"due_date,omitempty"` // Fecha como string plano
 }
 
-// UpdateTaskStatusRequest modelo para cambiar estado
+// TaskPatch cambios parciales de una tarea según JSON Merge Patch (RFC 7396). Solo
+// contiene los campos presentes en el request, con la columna como clave; nil limpia
+// la columna. Los valores son string salvo due_date (time.Time)
+type TaskPatch map[string]interface{}
+
+// TaskPatchFields campos que acepta PATCH /tasks/:id, indicando si admiten null
+var TaskPatchFields = map[string]bool{
+	"title":       false,
+	"description": true,
+	"priority":    false,
+	"due_date":    true,
+}
+
+// UpdateTaskStatusRequest modelo para cambiar estado. AllowOpenSubtasks permite completar
+// una tarea con subtareas abiertas (con advertencia)
 type UpdateTaskStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=pending in_progress completed cancelled"`
+	Status            string `json:"status" binding:"required,oneof=pending in_progress completed cancelled"`
+	AllowOpenSubtasks bool   `json:"allow_open_subtasks,omitempty"`
+}
+
+// SetParentTaskRequest modelo para mover una tarea bajo otra; null la convierte en tarea raíz
+type SetParentTaskRequest struct {
+	ParentID *string `json:"parent_task_id" binding:"omitempty,uuid"`
+}
+
+// SetRecurrenceRequest modelo para definir la regla de repetición de una tarea.
+// La serie termina en ends_at o al generar max_occurrences ocurrencias
+type SetRecurrenceRequest struct {
+	Frequency      string  `json:"frequency" binding:"required,oneof=daily weekly monthly"`
+	Interval       int     `json:"interval,omitempty" binding:"omitempty,min=1,max=365"`
+	Weekdays       []int   `json:"weekdays,omitempty" binding:"omitempty,dive,min=0,max=6"`
+	MonthDay       *int    `json:"month_day,omitempty" binding:"omitempty,min=1,max=31"`
+	EndsAt         *string `json:"ends_at,omitempty"`
+	MaxOccurrences *int    `json:"max_occurrences,omitempty" binding:"omitempty,min=1"`
+}
+
+// CreateLabelRequest modelo para crear una etiqueta. personal=true la crea solo para el usuario
+type CreateLabelRequest struct {
+	Name     string `json:"name" binding:"required,max=50"`
+	Color    string `json:"color,omitempty" binding:"omitempty,hexcolor"`
+	Personal bool   `json:"personal,omitempty"`
+}
+
+// UpdateLabelRequest modelo para actualizar una etiqueta
+type UpdateLabelRequest struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,max=50"`
+	Color *string `json:"color,omitempty" binding:"omitempty,hexcolor"`
+}
+
+// AttachLabelRequest modelo para aplicar una etiqueta a una tarea
+type AttachLabelRequest struct {
+	LabelID string `json:"label_id" binding:"required,uuid"`
+}
+
+// AddDependencyRequest modelo para registrar una dependencia entre la tarea de la
+// ruta y task_id. Con type=blocked_by (por defecto) task_id bloquea a la tarea;
+// con type=blocks la tarea bloquea a task_id
+type AddDependencyRequest struct {
+	TaskID string `json:"task_id" binding:"required,uuid"`
+	Type   string `json:"type,omitempty" binding:"omitempty,oneof=blocks blocked_by"`
 }
 
-// AssignTaskRequest modelo para asignar tarea
+// CreateChecklistItemRequest modelo para agregar un ítem a la checklist.
+// Sin posición, el ítem se agrega al final
+type CreateChecklistItemRequest struct {
+	Text     string `json:"text" binding:"required,max=200"`
+	Position *int   `json:"position,omitempty" binding:"omitempty,min=0"`
+}
+
+// UpdateChecklistItemRequest modelo para editar, marcar o mover un ítem de la checklist
+type UpdateChecklistItemRequest struct {
+	Text     *string `json:"text,omitempty" binding:"omitempty,max=200"`
+	Done     *bool   `json:"done,omitempty"`
+	Position *int    `json:"position,omitempty" binding:"omitempty,min=0"`
+}
+
+// AssignTaskRequest modelo para asignar tarea; null quita a todos los responsables
 type AssignTaskRequest struct {
-	AssignedTo *string `json:"assigned_to"`
+	AssignedTo *string `json:"assigned_to" binding:"omitempty,uuid"`
+}
+
+// AddAssigneeRequest modelo para agregar un responsable a la tarea
+type AddAssigneeRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+// AddWatcherRequest modelo para seguir una tarea; sin user_id sigue el propio usuario
+type AddWatcherRequest struct {
+	UserID *string `json:"user_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// Operaciones de POST /tasks/bulk
+const (
+	BulkOpUpdateStatus = "update_status"
+	BulkOpAssign       = "assign"
+	BulkOpSetPriority  = "set_priority"
+	BulkOpDelete       = "delete"
+	BulkOpAddLabel     = "add_label"
+)
+
+// BulkTaskRequest modelo para aplicar una operación a varias tareas. Cada operación
+// usa su campo: status (update_status), assigned_to (assign; null desasigna),
+// priority (set_priority) o label_id (add_label). Con atomic=true se aplica a todas
+// las tareas o a ninguna; si no, cada tarea se procesa por separado
+type BulkTaskRequest struct {
+	Operation         string   `json:"operation" binding:"required,oneof=update_status assign set_priority delete add_label"`
+	TaskIDs           []string `json:"task_ids" binding:"required,min=1,dive,uuid"`
+	Status            string   `json:"status,omitempty" binding:"omitempty,oneof=pending in_progress completed cancelled"`
+	AllowOpenSubtasks bool     `json:"allow_open_subtasks,omitempty"`
+	AssignedTo        *string  `json:"assigned_to,omitempty" binding:"omitempty,uuid"`
+	Priority          string   `json:"priority,omitempty" binding:"omitempty,oneof=low medium high urgent"`
+	LabelID           string   `json:"label_id,omitempty" binding:"omitempty,uuid"`
+	Atomic            bool     `json:"atomic,omitempty"`
+}
+
+// BulkTaskResult resultado de la operación sobre una tarea. Code es 200 si se aplicó
+// o el código del error que lo impidió
+type BulkTaskResult struct {
+	TaskID  string `json:"task_id"`
+	Success bool   `json:"success"`
+	Code    int    `json:"code"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkTaskResponse resultados por tarea en el orden de task_ids (sin repetidos)
+type BulkTaskResponse struct {
+	Results    []BulkTaskResult `json:"results"`
+	Succeeded  int              `json:"succeeded"`
+	Failed     int              `json:"failed"`
+	RolledBack bool             `json:"rolled_back"` // lote atómico revertido: no se aplicó a ninguna tarea
 }
 
 // TasksListResponse respuesta con lista de tareas
+// En modo cursor, Total solo se calcula si se solicita y NextCursor queda vacío en la última página
 type TasksListResponse struct {
 	Tasks      []Task `json:"tasks"`
 	Total      int    `json:"total"`
 	Page       int    `json:"page"`
 	PageSize   int    `json:"page_size"`
 	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // APIResponse respuesta genérica de API
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// IdempotencyStore implementa domain.IdempotencyStore en memoria. Pensado para tests:
+// las claves no se comparten entre instancias y se pierden al reiniciar
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+	now     func() time.Time
+}
+
+// NewIdempotencyStore crea una nueva instancia de IdempotencyStore
+func NewIdempotencyStore() domain.IdempotencyStore {
+	return &IdempotencyStore{
+		records: make(map[string]*models.IdempotencyRecord),
+		now:     time.Now,
+	}
+}
+
+// Reserve registra la clave si no existe o ya venció; si no, retorna una copia del registro vigente
+func (s *IdempotencyStore) Reserve(ctx context.Context, userID, key, fingerprint string, ttl time.Duration) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := recordID(userID, key)
+	if record, ok := s.records[id]; ok && record.ExpiresAt.After(s.now()) {
+		existing := *record
+		return &existing, nil
+	}
+
+	s.records[id] = &models.IdempotencyRecord{
+		Fingerprint: fingerprint,
+		ExpiresAt:   s.now().Add(ttl),
+	}
+	return nil, nil
+}
+
+// Complete guarda la respuesta del request
+func (s *IdempotencyStore) Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[recordID(userID, key)]; ok {
+		record.StatusCode = statusCode
+		record.ContentType = contentType
+		record.Body = append([]byte(nil), body...)
+	}
+	return nil
+}
+
+// Release elimina la reserva si su request no llegó a guardar respuesta
+func (s *IdempotencyStore) Release(ctx context.Context, userID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := recordID(userID, key)
+	if record, ok := s.records[id]; ok && record.StatusCode == 0 {
+		delete(s.records, id)
+	}
+	return nil
+}
+
+// DeleteExpired elimina las claves vencidas
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	deleted := 0
+	for id, record := range s.records {
+		if !record.ExpiresAt.After(now) {
+			delete(s.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// recordID clave del mapa para un usuario y su Idempotency-Key
+func recordID(userID, key string) string {
+	return userID + "\x00" + key
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// AttachmentRepository implementa domain.AttachmentRepository usando PostgreSQL
+type AttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewAttachmentRepository crea una nueva instancia de AttachmentRepository
+func NewAttachmentRepository(db *sql.DB) domain.AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// attachmentColumns columnas seleccionadas para construir un models.Attachment con scanAttachment
+const attachmentColumns = "id, task_id, file_name, content_type, size, checksum, storage_key, uploaded_by, created_at"
+
+// scanAttachment escanea una fila con las columnas de attachmentColumns
+func scanAttachment(row rowScanner) (*models.Attachment, error) {
+	var attachment models.Attachment
+	var uploadedBy sql.NullString
+
+	err := row.Scan(
+		&attachment.ID, &attachment.TaskID, &attachment.FileName, &attachment.ContentType, &attachment.Size,
+		&attachment.Checksum, &attachment.StorageKey, &uploadedBy, &attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if uploadedBy.Valid {
+		attachment.UploadedBy = &uploadedBy.String
+	}
+
+	return &attachment, nil
+}
+
+// ListByTask obtiene los adjuntos de una tarea, del más antiguo al más reciente
+func (r *AttachmentRepository) ListByTask(ctx context.Context, taskID string) ([]models.Attachment, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT "+attachmentColumns+" FROM task_attachments WHERE task_id = $1::UUID ORDER BY created_at ASC",
+		taskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener adjuntos: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear adjunto: %w", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// GetByID obtiene un adjunto de la tarea
+func (r *AttachmentRepository) GetByID(ctx context.Context, taskID, id string) (*models.Attachment, error) {
+	attachment, err := scanAttachment(r.db.QueryRowContext(
+		ctx,
+		"SELECT "+attachmentColumns+" FROM task_attachments WHERE id = $1::UUID AND task_id = $2::UUID",
+		id, taskID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("error al obtener adjunto: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// Create registra un adjunto ya guardado en el BlobStore y lo anota en el historial de la tarea
+func (r *AttachmentRepository) Create(ctx context.Context, workspaceID string, attachment *models.Attachment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, attachment.TaskID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO task_attachments (id, task_id, file_name, content_type, size, checksum, storage_key, uploaded_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		attachment.ID, attachment.TaskID, attachment.FileName, attachment.ContentType, attachment.Size,
+		attachment.Checksum, attachment.StorageKey, attachment.UploadedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("error al crear adjunto: %w", err)
+	}
+
+	actorID := ""
+	if attachment.UploadedBy != nil {
+		actorID = *attachment.UploadedBy
+	}
+
+	changes := appendChange(nil, "attachments", nil, &attachment.FileName)
+	if err := recordTaskEvents(ctx, tx, workspaceID, attachment.TaskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar adjunto: %w", err)
+	}
+
+	return nil
+}
+
+// Delete elimina el registro de un adjunto y lo anota en el historial de la tarea
+func (r *AttachmentRepository) Delete(ctx context.Context, workspaceID, taskID, id, actorID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return err
+	}
+
+	var fileName string
+	err = tx.QueryRowContext(
+		ctx,
+		"DELETE FROM task_attachments WHERE id = $1::UUID AND task_id = $2::UUID RETURNING file_name",
+		id, taskID,
+	).Scan(&fileName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrAttachmentNotFound
+		}
+		return fmt.Errorf("error al eliminar adjunto: %w", err)
+	}
+
+	changes := appendChange(nil, "attachments", &fileName, nil)
+	if err := recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación de adjunto: %w", err)
+	}
+
+	return nil
+}
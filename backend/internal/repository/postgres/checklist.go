@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// ChecklistRepository implementa domain.ChecklistRepository usando PostgreSQL
+type ChecklistRepository struct {
+	db *sql.DB
+}
+
+// NewChecklistRepository crea una nueva instancia de ChecklistRepository
+func NewChecklistRepository(db *sql.DB) domain.ChecklistRepository {
+	return &ChecklistRepository{db: db}
+}
+
+// checklistColumns columnas seleccionadas para construir un models.ChecklistItem con scanChecklistItem
+const checklistColumns = "id, task_id, text, done, position, created_at, updated_at"
+
+// scanChecklistItem escanea una fila con las columnas de checklistColumns
+func scanChecklistItem(row rowScanner) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+
+	err := row.Scan(&item.ID, &item.TaskID, &item.Text, &item.Done, &item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// List obtiene los ítems de la checklist ordenados por posición
+func (r *ChecklistRepository) List(ctx context.Context, taskID string) ([]models.ChecklistItem, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT "+checklistColumns+" FROM task_checklist_items WHERE task_id = $1::UUID ORDER BY position ASC, created_at ASC",
+		taskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener checklist: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.ChecklistItem{}
+	for rows.Next() {
+		item, err := scanChecklistItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear ítem de checklist: %w", err)
+		}
+		items = append(items, *item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetByID obtiene un ítem de la checklist de la tarea
+func (r *ChecklistRepository) GetByID(ctx context.Context, taskID, id string) (*models.ChecklistItem, error) {
+	item, err := scanChecklistItem(r.db.QueryRowContext(
+		ctx,
+		"SELECT "+checklistColumns+" FROM task_checklist_items WHERE id = $1::UUID AND task_id = $2::UUID",
+		id, taskID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrChecklistItemNotFound
+		}
+		return nil, fmt.Errorf("error al obtener ítem de checklist: %w", err)
+	}
+
+	return item, nil
+}
+
+// Create agrega un ítem a la checklist; sin posición se agrega al final. Cambia el
+// progreso de la tarea, así que también su versión y su historial
+func (r *ChecklistRepository) Create(ctx context.Context, workspaceID, taskID, text string, position *int, actorID string) (string, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return "", fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return "", err
+	}
+
+	var itemID string
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO task_checklist_items (task_id, text, position)
+		 VALUES ($1, $2, COALESCE($3, (SELECT COALESCE(MAX(position) + 1, 0) FROM task_checklist_items WHERE task_id = $1::UUID)))
+		 RETURNING id`,
+		taskID, text, position,
+	).Scan(&itemID)
+	if err != nil {
+		return "", fmt.Errorf("error al crear ítem de checklist: %w", err)
+	}
+
+	changes := appendChange(nil, "checklist", nil, checklistEventValue(text, false))
+	if err := recordChecklistChange(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error al confirmar ítem de checklist: %w", err)
+	}
+
+	return itemID, nil
+}
+
+// Update actualiza texto, estado y posición de un ítem
+func (r *ChecklistRepository) Update(ctx context.Context, workspaceID, taskID, id, text string, done bool, position int, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return err
+	}
+
+	var oldText string
+	var oldDone bool
+	err = tx.QueryRowContext(
+		ctx,
+		"SELECT text, done FROM task_checklist_items WHERE id = $1::UUID AND task_id = $2::UUID FOR UPDATE",
+		id, taskID,
+	).Scan(&oldText, &oldDone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrChecklistItemNotFound
+		}
+		return fmt.Errorf("error al obtener ítem de checklist: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE task_checklist_items SET text=$3, done=$4, position=$5, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID AND task_id=$2::UUID",
+		id, taskID, text, done, position,
+	)
+	if err != nil {
+		return fmt.Errorf("error al actualizar ítem de checklist: %w", err)
+	}
+
+	changes := appendChange(nil, "checklist", checklistEventValue(oldText, oldDone), checklistEventValue(text, done))
+	if err := recordChecklistChange(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar ítem de checklist: %w", err)
+	}
+
+	return nil
+}
+
+// Delete elimina un ítem de la checklist
+func (r *ChecklistRepository) Delete(ctx context.Context, workspaceID, taskID, id, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return err
+	}
+
+	var text string
+	var done bool
+	err = tx.QueryRowContext(
+		ctx,
+		"DELETE FROM task_checklist_items WHERE id = $1::UUID AND task_id = $2::UUID RETURNING text, done",
+		id, taskID,
+	).Scan(&text, &done)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrChecklistItemNotFound
+		}
+		return fmt.Errorf("error al eliminar ítem de checklist: %w", err)
+	}
+
+	changes := appendChange(nil, "checklist", checklistEventValue(text, done), nil)
+	if err := recordChecklistChange(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar ítem de checklist: %w", err)
+	}
+
+	return nil
+}
+
+// recordChecklistChange sube la versión de la tarea, cuyo progreso de checklist forma
+// parte de su contenido, y registra el cambio del ítem en su historial
+func recordChecklistChange(ctx context.Context, tx dbtx, workspaceID, taskID, actorID string, changes []taskChange) error {
+	if err := touchTask(ctx, tx, taskID); err != nil {
+		return err
+	}
+	return recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes)
+}
+
+// checklistEventValue representa un ítem como texto para el historial: "[x] texto"
+// si está hecho, "[ ] texto" si no
+func checklistEventValue(text string, done bool) *string {
+	mark := "[ ] "
+	if done {
+		mark = "[x] "
+	}
+	value := mark + text
+	return &value
+}
@@ -0,0 +1,366 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// CommentRepository implementa domain.CommentRepository usando PostgreSQL
+type CommentRepository struct {
+	db *sql.DB
+}
+
+// NewCommentRepository crea una nueva instancia de CommentRepository
+func NewCommentRepository(db *sql.DB) domain.CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// commentColumns columnas seleccionadas para construir un models.Comment con scanComment.
+// Requiere el alias c para task_comments y u para users
+const commentColumns = "c.id, c.task_id, c.parent_id, c.author_id, u.name, c.body, c.edited_at, c.created_at, c.updated_at"
+
+// scanComment escanea una fila con las columnas de commentColumns
+func scanComment(row rowScanner) (*models.Comment, error) {
+	var comment models.Comment
+	var parentID sql.NullString
+	var editedAt sql.NullTime
+
+	err := row.Scan(
+		&comment.ID, &comment.TaskID, &parentID, &comment.AuthorID, &comment.AuthorName,
+		&comment.Body, &editedAt, &comment.CreatedAt, &comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		comment.ParentID = &parentID.String
+	}
+	if editedAt.Valid {
+		comment.EditedAt = &editedAt.Time
+	}
+	comment.Mentions = []models.CommentMention{}
+
+	return &comment, nil
+}
+
+// Create crea un comentario y registra sus menciones en una transacción
+func (r *CommentRepository) Create(ctx context.Context, taskID string, parentID *string, authorID, body string, mentionIDs []string) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	var commentID string
+	err = tx.QueryRowContext(
+		ctx,
+		"INSERT INTO task_comments (task_id, parent_id, author_id, body) VALUES ($1, $2, $3, $4) RETURNING id",
+		taskID, parentID, authorID, body,
+	).Scan(&commentID)
+	if err != nil {
+		log.Printf("🔴 ERROR en Create Comment - Database Error: %v (type: %T)\n", err, err)
+		return "", fmt.Errorf("error al crear comentario: %w", err)
+	}
+
+	if err := insertMentions(ctx, tx, commentID, mentionIDs); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error al confirmar comentario: %w", err)
+	}
+
+	log.Printf("✅ Comment created successfully: %s\n", commentID)
+	return commentID, nil
+}
+
+// GetByID obtiene un comentario por ID dentro del workspace
+func (r *CommentRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.Comment, error) {
+	comment, err := scanComment(r.db.QueryRowContext(
+		ctx,
+		`SELECT `+commentColumns+`
+		 FROM task_comments c
+		 JOIN users u ON u.id = c.author_id
+		 JOIN tasks t ON t.id = c.task_id
+		 WHERE c.id = $1::UUID AND t.workspace_id = $2::UUID AND t.deleted_at IS NULL`,
+		id, workspaceID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("error al obtener comentario: %w", err)
+	}
+
+	comments := []*models.Comment{comment}
+	if err := r.loadMentions(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// ListByTask obtiene los comentarios raíz de una tarea paginados, con sus respuestas.
+// Las respuestas y menciones se cargan en lote para la página completa
+func (r *CommentRepository) ListByTask(ctx context.Context, taskID string, page, pageSize int) ([]models.Comment, int, error) {
+	offset := (page - 1) * pageSize
+
+	var total int
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM task_comments WHERE task_id = $1::UUID AND parent_id IS NULL",
+		taskID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al contar comentarios: %w", err)
+	}
+
+	roots, err := r.queryComments(
+		ctx,
+		`SELECT `+commentColumns+`
+		 FROM task_comments c
+		 JOIN users u ON u.id = c.author_id
+		 WHERE c.task_id = $1::UUID AND c.parent_id IS NULL
+		 ORDER BY c.created_at ASC
+		 LIMIT $2 OFFSET $3`,
+		taskID, pageSize, offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	comments := make([]models.Comment, len(roots))
+	if len(roots) == 0 {
+		return comments, total, nil
+	}
+
+	rootIDs := make([]string, len(roots))
+	for i := range roots {
+		rootIDs[i] = roots[i].ID
+	}
+
+	replies, err := r.queryComments(
+		ctx,
+		`SELECT `+commentColumns+`
+		 FROM task_comments c
+		 JOIN users u ON u.id = c.author_id
+		 WHERE c.parent_id = ANY($1::UUID[])
+		 ORDER BY c.created_at ASC`,
+		pq.Array(rootIDs),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	all := make([]*models.Comment, 0, len(roots)+len(replies))
+	for i := range roots {
+		all = append(all, &roots[i])
+	}
+	for i := range replies {
+		all = append(all, &replies[i])
+	}
+	if err := r.loadMentions(ctx, all); err != nil {
+		return nil, 0, err
+	}
+
+	repliesByParent := make(map[string][]models.Comment)
+	for _, reply := range replies {
+		repliesByParent[*reply.ParentID] = append(repliesByParent[*reply.ParentID], reply)
+	}
+
+	for i, root := range roots {
+		root.Replies = repliesByParent[root.ID]
+		if root.Replies == nil {
+			root.Replies = []models.Comment{}
+		}
+		comments[i] = root
+	}
+
+	return comments, total, nil
+}
+
+// Update guarda el cuerpo anterior en el historial, actualiza el comentario y reemplaza sus menciones
+func (r *CommentRepository) Update(ctx context.Context, id, body, editedBy string, mentionIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO task_comment_edits (comment_id, previous_body, edited_by)
+		 SELECT id, body, $2 FROM task_comments WHERE id = $1::UUID`,
+		id, editedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("error al guardar historial del comentario: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al actualizar comentario: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrCommentNotFound
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE task_comments SET body=$2, edited_at=CURRENT_TIMESTAMP, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
+		id, body,
+	)
+	if err != nil {
+		return fmt.Errorf("error al actualizar comentario: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_comment_mentions WHERE comment_id = $1::UUID", id); err != nil {
+		return fmt.Errorf("error al actualizar menciones: %w", err)
+	}
+
+	if err := insertMentions(ctx, tx, id, mentionIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar comentario: %w", err)
+	}
+
+	return nil
+}
+
+// Delete elimina un comentario; sus respuestas, menciones e historial se eliminan en cascada
+func (r *CommentRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM task_comments WHERE id = $1::UUID", id)
+	if err != nil {
+		return fmt.Errorf("error al eliminar comentario: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al eliminar comentario: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// GetHistory obtiene las versiones anteriores de un comentario, de la más reciente a la más antigua
+func (r *CommentRepository) GetHistory(ctx context.Context, id string) ([]models.CommentEdit, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, comment_id, previous_body, edited_by, edited_at
+		 FROM task_comment_edits
+		 WHERE comment_id = $1::UUID
+		 ORDER BY edited_at DESC`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener historial del comentario: %w", err)
+	}
+	defer rows.Close()
+
+	edits := []models.CommentEdit{}
+	for rows.Next() {
+		var edit models.CommentEdit
+		if err := rows.Scan(&edit.ID, &edit.CommentID, &edit.PreviousBody, &edit.EditedBy, &edit.EditedAt); err != nil {
+			return nil, fmt.Errorf("error al escanear historial del comentario: %w", err)
+		}
+		edits = append(edits, edit)
+	}
+
+	return edits, rows.Err()
+}
+
+// queryComments ejecuta una consulta que selecciona commentColumns
+func (r *CommentRepository) queryComments(ctx context.Context, query string, args ...interface{}) ([]models.Comment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener comentarios: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear comentario: %w", err)
+		}
+		comments = append(comments, *comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// loadMentions carga en una sola consulta las menciones de los comentarios recibidos
+func (r *CommentRepository) loadMentions(ctx context.Context, comments []*models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Comment, len(comments))
+	ids := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+		ids = append(ids, comment.ID)
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT m.comment_id, u.id, u.email
+		 FROM task_comment_mentions m
+		 JOIN users u ON u.id = m.user_id
+		 WHERE m.comment_id = ANY($1::UUID[])
+		 ORDER BY u.email ASC`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("error al obtener menciones: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID string
+		var mention models.CommentMention
+		if err := rows.Scan(&commentID, &mention.UserID, &mention.Email); err != nil {
+			return fmt.Errorf("error al escanear mención: %w", err)
+		}
+		if comment, ok := byID[commentID]; ok {
+			comment.Mentions = append(comment.Mentions, mention)
+		}
+	}
+
+	return rows.Err()
+}
+
+// insertMentions registra las menciones de un comentario dentro de la transacción
+func insertMentions(ctx context.Context, tx *sql.Tx, commentID string, mentionIDs []string) error {
+	if len(mentionIDs) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO task_comment_mentions (comment_id, user_id)
+		 SELECT $1::UUID, UNNEST($2::UUID[])
+		 ON CONFLICT DO NOTHING`,
+		commentID, pq.Array(mentionIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("error al registrar menciones: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// DependencyRepository implementa domain.DependencyRepository usando PostgreSQL
+type DependencyRepository struct {
+	db *sql.DB
+}
+
+// NewDependencyRepository crea una nueva instancia de DependencyRepository
+func NewDependencyRepository(db *sql.DB) domain.DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+// LockGraph bloquea el grafo de dependencias del workspace hasta que termine la
+// transacción ambiente
+func (r *DependencyRepository) LockGraph(ctx context.Context, workspaceID string) error {
+	return lockWorkspaceGraph(ctx, conn(ctx, r.db), "task_dependencies", workspaceID)
+}
+
+// Add registra que blockerID bloquea a blockedID y lo anota en el historial de la tarea bloqueada
+func (r *DependencyRepository) Add(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, blockedID); err != nil {
+		return err
+	}
+
+	// La tarea bloqueadora debe pertenecer al mismo workspace
+	var exists bool
+	err = tx.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1::UUID AND workspace_id = $2::UUID AND deleted_at IS NULL)",
+		blockerID, workspaceID,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("error al verificar tarea bloqueadora: %w", err)
+	}
+	if !exists {
+		return errors.ErrTaskNotFound
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO task_dependencies (blocker_task_id, blocked_task_id, created_by)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (blocker_task_id, blocked_task_id) DO NOTHING`,
+		blockerID, blockedID, actorID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al crear dependencia: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al crear dependencia: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewAppError(409, "La dependencia ya existe", "")
+	}
+
+	changes := appendChange(nil, "blocked_by", nil, &blockerID)
+	if err := recordTaskEvents(ctx, tx, workspaceID, blockedID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar dependencia: %w", err)
+	}
+
+	return nil
+}
+
+// Remove elimina la dependencia y lo anota en el historial de la tarea bloqueada
+func (r *DependencyRepository) Remove(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, blockedID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM task_dependencies WHERE blocker_task_id = $1::UUID AND blocked_task_id = $2::UUID",
+		blockerID, blockedID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al eliminar dependencia: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al eliminar dependencia: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrDependencyNotFound
+	}
+
+	changes := appendChange(nil, "blocked_by", &blockerID, nil)
+	if err := recordTaskEvents(ctx, tx, workspaceID, blockedID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación de dependencia: %w", err)
+	}
+
+	return nil
+}
+
+// ListEdges obtiene todas las dependencias entre tareas del workspace
+func (r *DependencyRepository) ListEdges(ctx context.Context, workspaceID string) ([]models.TaskDependencyEdge, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT d.blocker_task_id, d.blocked_task_id
+		 FROM task_dependencies d
+		 JOIN tasks t ON t.id = d.blocked_task_id
+		 WHERE t.workspace_id = $1::UUID`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener dependencias: %w", err)
+	}
+	defer rows.Close()
+
+	edges := []models.TaskDependencyEdge{}
+	for rows.Next() {
+		var edge models.TaskDependencyEdge
+		if err := rows.Scan(&edge.BlockerID, &edge.BlockedID); err != nil {
+			return nil, fmt.Errorf("error al escanear dependencia: %w", err)
+		}
+		edges = append(edges, edge)
+	}
+
+	return edges, rows.Err()
+}
+
+// CountOpenBlockers cuenta los bloqueadores directos que no están completados, cancelados ni en la papelera
+func (r *DependencyRepository) CountOpenBlockers(ctx context.Context, workspaceID, taskID string) (int, error) {
+	var count int
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*)
+		 FROM task_dependencies d
+		 JOIN tasks t ON t.id = d.blocker_task_id
+		 WHERE d.blocked_task_id = $1::UUID AND t.workspace_id = $2::UUID
+		   AND t.deleted_at IS NULL AND t.status NOT IN ('completed', 'cancelled')`,
+		taskID, workspaceID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error al contar bloqueadores abiertos: %w", err)
+	}
+
+	return count, nil
+}
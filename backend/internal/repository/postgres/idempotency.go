@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// IdempotencyStore implementa domain.IdempotencyStore usando PostgreSQL
+type IdempotencyStore struct {
+	db *sql.DB
+}
+
+// NewIdempotencyStore crea una nueva instancia de IdempotencyStore
+func NewIdempotencyStore(db *sql.DB) domain.IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+// Reserve inserta la clave, o la reutiliza si ya venció. Si hay un registro vigente
+// lo retorna sin modificarlo
+func (s *IdempotencyStore) Reserve(ctx context.Context, userID, key, fingerprint string, ttl time.Duration) (*models.IdempotencyRecord, error) {
+	var reserved string
+	err := s.db.QueryRowContext(
+		ctx,
+		`INSERT INTO idempotency_keys (user_id, idempotency_key, fingerprint, expires_at)
+		 VALUES ($1, $2, $3, CURRENT_TIMESTAMP + $4 * INTERVAL '1 second')
+		 ON CONFLICT (user_id, idempotency_key) DO UPDATE
+		 SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, content_type = NULL, body = NULL,
+			created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+		 RETURNING idempotency_key`,
+		userID, key, fingerprint, int64(ttl/time.Second),
+	).Scan(&reserved)
+	if err == nil {
+		return nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("error al reservar idempotency key: %w", err)
+	}
+
+	var record models.IdempotencyRecord
+	var statusCode sql.NullInt64
+	var contentType sql.NullString
+	err = s.db.QueryRowContext(
+		ctx,
+		`SELECT fingerprint, status_code, content_type, body, expires_at
+		 FROM idempotency_keys WHERE user_id = $1::UUID AND idempotency_key = $2`,
+		userID, key,
+	).Scan(&record.Fingerprint, &statusCode, &contentType, &record.Body, &record.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Se eliminó entre ambas consultas (venció y se purgó)
+			return nil, errors.NewAppError(409, "El Idempotency-Key cambió de estado; reintente", "")
+		}
+		return nil, fmt.Errorf("error al obtener idempotency key: %w", err)
+	}
+
+	record.StatusCode = int(statusCode.Int64)
+	record.ContentType = contentType.String
+	return &record, nil
+}
+
+// Complete guarda la respuesta del request
+func (s *IdempotencyStore) Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`UPDATE idempotency_keys SET status_code = $3, content_type = $4, body = $5
+		 WHERE user_id = $1::UUID AND idempotency_key = $2`,
+		userID, key, statusCode, contentType, body,
+	)
+	if err != nil {
+		return fmt.Errorf("error al guardar respuesta idempotente: %w", err)
+	}
+	return nil
+}
+
+// Release elimina la reserva si su request no llegó a guardar respuesta
+func (s *IdempotencyStore) Release(ctx context.Context, userID, key string) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"DELETE FROM idempotency_keys WHERE user_id = $1::UUID AND idempotency_key = $2 AND status_code IS NULL",
+		userID, key,
+	)
+	if err != nil {
+		return fmt.Errorf("error al liberar idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired elimina las claves vencidas
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= CURRENT_TIMESTAMP")
+	if err != nil {
+		return 0, fmt.Errorf("error al eliminar idempotency keys vencidas: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error al eliminar idempotency keys vencidas: %w", err)
+	}
+	return int(deleted), nil
+}
@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// LabelRepository implementa domain.LabelRepository usando PostgreSQL
+type LabelRepository struct {
+	db *sql.DB
+}
+
+// NewLabelRepository crea una nueva instancia de LabelRepository
+func NewLabelRepository(db *sql.DB) domain.LabelRepository {
+	return &LabelRepository{db: db}
+}
+
+// labelColumns columnas seleccionadas para construir un models.Label con scanLabel
+const labelColumns = "l.id, l.workspace_id, l.user_id, l.name, l.color, l.created_by, l.created_at, l.updated_at"
+
+// scanLabel escanea una fila con las columnas de labelColumns
+func scanLabel(row rowScanner, extra ...interface{}) (*models.Label, error) {
+	var label models.Label
+	var userID, createdBy sql.NullString
+
+	dest := append(extra, &label.ID, &label.WorkspaceID, &userID, &label.Name, &label.Color, &createdBy, &label.CreatedAt, &label.UpdatedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		label.UserID = &userID.String
+	}
+	if createdBy.Valid {
+		label.CreatedBy = &createdBy.String
+	}
+
+	return &label, nil
+}
+
+// loadTaskLabels carga las etiquetas de todas las tareas con una sola consulta. De las
+// personales solo carga las de viewerID: las de otros usuarios no se muestran aunque
+// estén en la tarea
+func loadTaskLabels(ctx context.Context, db dbtx, tasks []models.Task, viewerID string) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(tasks))
+	index := make(map[string]int, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+		index[tasks[i].ID] = i
+		tasks[i].Labels = []models.Label{}
+	}
+
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT tl.task_id, `+labelColumns+`
+		 FROM task_labels tl
+		 JOIN labels l ON l.id = tl.label_id
+		 WHERE tl.task_id = ANY($1::UUID[])
+		   AND (l.user_id IS NULL OR l.user_id = NULLIF($2, '')::UUID)
+		 ORDER BY LOWER(l.name) ASC`,
+		pq.Array(ids), viewerID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al obtener etiquetas de tareas: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID string
+		label, err := scanLabel(rows, &taskID)
+		if err != nil {
+			return fmt.Errorf("error al escanear etiqueta: %w", err)
+		}
+
+		i := index[taskID]
+		tasks[i].Labels = append(tasks[i].Labels, *label)
+	}
+
+	return rows.Err()
+}
+
+// List obtiene las etiquetas del workspace y las personales del usuario
+func (r *LabelRepository) List(ctx context.Context, workspaceID, userID string) ([]models.Label, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+labelColumns+` FROM labels l
+		 WHERE l.workspace_id = $1::UUID AND (l.user_id IS NULL OR l.user_id = $2::UUID)
+		 ORDER BY LOWER(l.name) ASC`,
+		workspaceID, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener etiquetas: %w", err)
+	}
+	defer rows.Close()
+
+	labels := []models.Label{}
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear etiqueta: %w", err)
+		}
+		labels = append(labels, *label)
+	}
+
+	return labels, rows.Err()
+}
+
+// GetByID obtiene una etiqueta del workspace
+func (r *LabelRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.Label, error) {
+	label, err := scanLabel(conn(ctx, r.db).QueryRowContext(
+		ctx,
+		"SELECT "+labelColumns+" FROM labels l WHERE l.id = $1::UUID AND l.workspace_id = $2::UUID",
+		id, workspaceID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrLabelNotFound
+		}
+		return nil, fmt.Errorf("error al obtener etiqueta: %w", err)
+	}
+
+	return label, nil
+}
+
+// Create crea una etiqueta; con userID la etiqueta es personal
+func (r *LabelRepository) Create(ctx context.Context, workspaceID string, userID *string, name, color, createdBy string) (string, error) {
+	var labelID string
+
+	err := r.db.QueryRowContext(
+		ctx,
+		"INSERT INTO labels (workspace_id, user_id, name, color, created_by) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		workspaceID, userID, name, color, createdBy,
+	).Scan(&labelID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return "", errors.ErrLabelExists
+		}
+		return "", fmt.Errorf("error al crear etiqueta: %w", err)
+	}
+
+	return labelID, nil
+}
+
+// Update actualiza nombre y color de una etiqueta
+func (r *LabelRepository) Update(ctx context.Context, workspaceID, id, name, color string) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE labels SET name=$3, color=$4, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID AND workspace_id=$2::UUID",
+		id, workspaceID, name, color,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.ErrLabelExists
+		}
+		return fmt.Errorf("error al actualizar etiqueta: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al actualizar etiqueta: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrLabelNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina una etiqueta y la quita de todas las tareas
+func (r *LabelRepository) Delete(ctx context.Context, workspaceID, id string) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM labels WHERE id = $1::UUID AND workspace_id = $2::UUID",
+		id, workspaceID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al eliminar etiqueta: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al eliminar etiqueta: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrLabelNotFound
+	}
+
+	return nil
+}
+
+// recordLabelChange registra en el historial de la tarea que se aplicó o quitó la
+// etiqueta. El historial lo ve cualquiera con acceso a la tarea, así que las
+// etiquetas personales no se registran
+func recordLabelChange(ctx context.Context, tx dbtx, workspaceID, taskID, actorID string, label *models.Label, attached bool) error {
+	if label.UserID != nil {
+		return nil
+	}
+
+	changes := appendChange(nil, "labels", &label.Name, nil)
+	if attached {
+		changes = appendChange(nil, "labels", nil, &label.Name)
+	}
+	return recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes)
+}
+
+// Attach aplica la etiqueta a la tarea y lo registra con recordLabelChange. Aplicar una
+// etiqueta que la tarea ya tiene no tiene efecto
+func (r *LabelRepository) Attach(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO task_labels (task_id, label_id) VALUES ($1, $2) ON CONFLICT (task_id, label_id) DO NOTHING",
+		taskID, label.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al aplicar etiqueta: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al aplicar etiqueta: %w", err)
+	}
+
+	if rowsAffected > 0 {
+		if err := touchTask(ctx, tx, taskID); err != nil {
+			return err
+		}
+		if err := recordLabelChange(ctx, tx, workspaceID, taskID, actorID, label, true); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar etiqueta: %w", err)
+	}
+
+	return nil
+}
+
+// Detach quita la etiqueta de la tarea y lo registra con recordLabelChange
+func (r *LabelRepository) Detach(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM task_labels WHERE task_id = $1::UUID AND label_id = $2::UUID",
+		taskID, label.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al quitar etiqueta: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al quitar etiqueta: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewAppError(404, "La tarea no tiene esa etiqueta", "")
+	}
+
+	if err := touchTask(ctx, tx, taskID); err != nil {
+		return err
+	}
+	if err := recordLabelChange(ctx, tx, workspaceID, taskID, actorID, label, false); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar etiqueta: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// ProjectRepository implementa domain.ProjectRepository usando PostgreSQL
+type ProjectRepository struct {
+	db *sql.DB
+}
+
+// NewProjectRepository crea una nueva instancia de ProjectRepository
+func NewProjectRepository(db *sql.DB) domain.ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+// projectColumns columnas seleccionadas para construir un models.Project con scanProject
+const projectColumns = "id, workspace_id, name, description, archived, owner_id, created_at, updated_at"
+
+// scanProject escanea una fila con las columnas de projectColumns
+func scanProject(row rowScanner) (*models.Project, error) {
+	var project models.Project
+	var description sql.NullString
+
+	err := row.Scan(
+		&project.ID, &project.WorkspaceID, &project.Name, &description,
+		&project.Archived, &project.OwnerID, &project.CreatedAt, &project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Description = description.String
+	return &project, nil
+}
+
+// Create crea un nuevo proyecto
+func (r *ProjectRepository) Create(ctx context.Context, workspaceID, name, description, ownerID string) (string, error) {
+	var projectID string
+
+	err := r.db.QueryRowContext(
+		ctx,
+		"INSERT INTO projects (workspace_id, name, description, owner_id) VALUES ($1, $2, $3, $4) RETURNING id",
+		workspaceID, name, description, ownerID,
+	).Scan(&projectID)
+	if err != nil {
+		log.Printf("🔴 ERROR en Create Project - Database Error: %v (type: %T)\n", err, err)
+		return "", fmt.Errorf("error al crear proyecto: %w", err)
+	}
+
+	log.Printf("✅ Project created successfully: %s\n", projectID)
+	return projectID, nil
+}
+
+// GetByID obtiene un proyecto por ID dentro del workspace
+func (r *ProjectRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.Project, error) {
+	project, err := scanProject(conn(ctx, r.db).QueryRowContext(
+		ctx,
+		"SELECT "+projectColumns+" FROM projects WHERE id = $1::UUID AND workspace_id = $2::UUID",
+		id, workspaceID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("proyecto no encontrado")
+		}
+		return nil, fmt.Errorf("error al obtener proyecto: %w", err)
+	}
+
+	return project, nil
+}
+
+// List obtiene los proyectos del workspace ordenados por nombre
+func (r *ProjectRepository) List(ctx context.Context, workspaceID string, includeArchived bool) ([]models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects WHERE workspace_id = $1::UUID"
+	if !includeArchived {
+		query += " AND archived = FALSE"
+	}
+	query += " ORDER BY name ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener proyectos: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear proyecto: %w", err)
+		}
+		projects = append(projects, *project)
+	}
+
+	return projects, rows.Err()
+}
+
+// Update actualiza un proyecto
+func (r *ProjectRepository) Update(ctx context.Context, workspaceID, id, name, description string, archived bool) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE projects SET name=$3, description=$4, archived=$5, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID AND workspace_id=$2::UUID",
+		id, workspaceID, name, description, archived,
+	)
+	if err != nil {
+		return fmt.Errorf("error al actualizar proyecto: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al actualizar proyecto: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina un proyecto; sus tareas quedan sin proyecto (ON DELETE SET NULL)
+func (r *ProjectRepository) Delete(ctx context.Context, workspaceID, id string) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM projects WHERE id = $1::UUID AND workspace_id = $2::UUID",
+		id, workspaceID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al eliminar proyecto: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al eliminar proyecto: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrProjectNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// RecurrenceRepository implementa domain.RecurrenceRepository usando PostgreSQL
+type RecurrenceRepository struct {
+	db *sql.DB
+}
+
+// NewRecurrenceRepository crea una nueva instancia de RecurrenceRepository
+func NewRecurrenceRepository(db *sql.DB) domain.RecurrenceRepository {
+	return &RecurrenceRepository{db: db}
+}
+
+// recurrenceColumns columnas seleccionadas para construir un models.TaskRecurrence con scanRecurrence
+const recurrenceColumns = `r.id, r.workspace_id, r.task_id, r.frequency, r.repeat_interval, r.weekdays, r.month_day,
+	r.starts_at, r.ends_at, r.max_occurrences, r.occurrences, r.last_task_id, r.last_due_at, r.active,
+	r.created_by, r.created_at, r.updated_at`
+
+// scanRecurrence escanea una fila con las columnas de recurrenceColumns
+func scanRecurrence(row rowScanner) (*models.TaskRecurrence, error) {
+	var rule models.TaskRecurrence
+	var weekdays pq.Int64Array
+	var monthDay, maxOccurrences sql.NullInt64
+	var endsAt sql.NullTime
+	var lastTaskID, createdBy sql.NullString
+
+	err := row.Scan(
+		&rule.ID, &rule.WorkspaceID, &rule.TaskID, &rule.Frequency, &rule.Interval, &weekdays, &monthDay,
+		&rule.StartsAt, &endsAt, &maxOccurrences, &rule.Occurrences, &lastTaskID, &rule.LastDueAt, &rule.Active,
+		&createdBy, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, day := range weekdays {
+		rule.Weekdays = append(rule.Weekdays, int(day))
+	}
+	if monthDay.Valid {
+		day := int(monthDay.Int64)
+		rule.MonthDay = &day
+	}
+	if endsAt.Valid {
+		rule.EndsAt = &endsAt.Time
+	}
+	if maxOccurrences.Valid {
+		max := int(maxOccurrences.Int64)
+		rule.MaxOccurrences = &max
+	}
+	if lastTaskID.Valid {
+		rule.LastTaskID = &lastTaskID.String
+	}
+	if createdBy.Valid {
+		rule.CreatedBy = &createdBy.String
+	}
+
+	return &rule, nil
+}
+
+// weekdaysArray convierte los días de la semana al tipo de arreglo de PostgreSQL; nil si no hay días
+func weekdaysArray(weekdays []int) interface{} {
+	if len(weekdays) == 0 {
+		return nil
+	}
+
+	days := make(pq.Int64Array, len(weekdays))
+	for i, day := range weekdays {
+		days[i] = int64(day)
+	}
+	return days
+}
+
+// GetByID obtiene una regla de repetición del workspace
+func (r *RecurrenceRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.TaskRecurrence, error) {
+	rule, err := scanRecurrence(r.db.QueryRowContext(
+		ctx,
+		"SELECT "+recurrenceColumns+" FROM task_recurrences r WHERE r.id = $1::UUID AND r.workspace_id = $2::UUID",
+		id, workspaceID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrRecurrenceNotFound
+		}
+		return nil, fmt.Errorf("error al obtener regla de repetición: %w", err)
+	}
+
+	return rule, nil
+}
+
+// Create crea la regla y asocia su tarea como primera ocurrencia
+func (r *RecurrenceRepository) Create(ctx context.Context, rule *models.TaskRecurrence, actorID string) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, rule.WorkspaceID, rule.TaskID); err != nil {
+		return "", err
+	}
+
+	var ruleID string
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO task_recurrences (workspace_id, task_id, frequency, repeat_interval, weekdays, month_day,
+		                               starts_at, ends_at, max_occurrences, last_task_id, last_due_at, created_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $2, $7, $10)
+		 RETURNING id`,
+		rule.WorkspaceID, rule.TaskID, rule.Frequency, rule.Interval, weekdaysArray(rule.Weekdays), rule.MonthDay,
+		rule.StartsAt, rule.EndsAt, rule.MaxOccurrences, actorID,
+	).Scan(&ruleID)
+	if err != nil {
+		return "", fmt.Errorf("error al crear regla de repetición: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE tasks SET recurrence_id=$2, version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
+		rule.TaskID, ruleID,
+	)
+	if err != nil {
+		return "", fmt.Errorf("error al asociar regla de repetición: %w", err)
+	}
+
+	changes := appendChange(nil, "recurrence", nil, &rule.Frequency)
+	if err := recordTaskEvents(ctx, tx, rule.WorkspaceID, rule.TaskID, actorID, changes); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error al confirmar regla de repetición: %w", err)
+	}
+
+	return ruleID, nil
+}
+
+// Update actualiza frecuencia, intervalo, días, fin y estado de la regla
+func (r *RecurrenceRepository) Update(ctx context.Context, rule *models.TaskRecurrence) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE task_recurrences
+		 SET frequency=$3, repeat_interval=$4, weekdays=$5, month_day=$6, ends_at=$7, max_occurrences=$8,
+		     active=$9, updated_at=CURRENT_TIMESTAMP
+		 WHERE id=$1::UUID AND workspace_id=$2::UUID`,
+		rule.ID, rule.WorkspaceID, rule.Frequency, rule.Interval, weekdaysArray(rule.Weekdays), rule.MonthDay,
+		rule.EndsAt, rule.MaxOccurrences, rule.Active,
+	)
+	if err != nil {
+		return fmt.Errorf("error al actualizar regla de repetición: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al actualizar regla de repetición: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrRecurrenceNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina la regla; las ocurrencias ya generadas se conservan sin regla
+func (r *RecurrenceRepository) Delete(ctx context.Context, workspaceID, id, actorID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	var taskID, frequency string
+	err = tx.QueryRowContext(
+		ctx,
+		"DELETE FROM task_recurrences WHERE id = $1::UUID AND workspace_id = $2::UUID RETURNING task_id, frequency",
+		id, workspaceID,
+	).Scan(&taskID, &frequency)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrRecurrenceNotFound
+		}
+		return fmt.Errorf("error al eliminar regla de repetición: %w", err)
+	}
+
+	changes := appendChange(nil, "recurrence", &frequency, nil)
+	if err := recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación de regla de repetición: %w", err)
+	}
+
+	return nil
+}
+
+// ListDue obtiene reglas activas cuya última ocurrencia ya venció, se completó,
+// se canceló o se eliminó (o está en la papelera), de la más atrasada a la más
+// reciente. Las reglas cuya tarea plantilla está en la papelera quedan en pausa
+func (r *RecurrenceRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]models.TaskRecurrence, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+recurrenceColumns+`
+		 FROM task_recurrences r
+		 JOIN tasks tpl ON tpl.id = r.task_id AND tpl.deleted_at IS NULL
+		 LEFT JOIN tasks t ON t.id = r.last_task_id
+		 WHERE r.active
+		   AND (t.id IS NULL OR t.deleted_at IS NOT NULL OR t.status IN ('completed', 'cancelled') OR r.last_due_at <= $1)
+		 ORDER BY r.last_due_at ASC
+		 LIMIT $2`,
+		now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener reglas de repetición pendientes: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []models.TaskRecurrence{}
+	for rows.Next() {
+		rule, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear regla de repetición: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// CreateOccurrence copia la tarea plantilla con vencimiento dueDate y avanza la regla.
+// La regla se bloquea y solo avanza si occurrences no cambió desde que se leyó; el
+// índice único (recurrence_id, due_date) impide duplicados aunque dos instancias
+// del generador coincidan. Retorna el ID solo si insertó la ocurrencia
+func (r *RecurrenceRepository) CreateOccurrence(ctx context.Context, rule *models.TaskRecurrence, dueDate time.Time) (string, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return "", fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked string
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT id FROM task_recurrences
+		 WHERE id = $1::UUID AND active AND occurrences = $2
+		 FOR UPDATE SKIP LOCKED`,
+		rule.ID, rule.Occurrences,
+	).Scan(&locked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("error al bloquear regla de repetición: %w", err)
+	}
+
+	var taskID, title, createdBy string
+	created := true
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO tasks (workspace_id, project_id, title, description, priority, due_date, created_by,
+			assigned_to, assigned_by, assigned_at, recurrence_id)
+		 SELECT workspace_id, project_id, title, description, priority, $2, created_by,
+			assigned_to, assigned_by, CASE WHEN assigned_to IS NULL THEN NULL ELSE CURRENT_TIMESTAMP END, $1
+		 FROM tasks WHERE id = $3::UUID
+		 ON CONFLICT (recurrence_id, due_date) WHERE recurrence_id IS NOT NULL DO NOTHING
+		 RETURNING id, title, created_by`,
+		rule.ID, dueDate, rule.TaskID,
+	).Scan(&taskID, &title, &createdBy)
+	if err == sql.ErrNoRows {
+		// La ocurrencia ya existía: solo se avanza la regla
+		err = tx.QueryRowContext(
+			ctx,
+			"SELECT id FROM tasks WHERE recurrence_id = $1::UUID AND due_date = $2",
+			rule.ID, dueDate,
+		).Scan(&taskID)
+		if err != nil {
+			return "", fmt.Errorf("error al obtener ocurrencia existente: %w", err)
+		}
+		created = false
+	} else if err != nil {
+		return "", fmt.Errorf("error al crear ocurrencia: %w", err)
+	} else {
+		// La ocurrencia hereda los responsables y seguidores de la plantilla
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO task_assignees (task_id, user_id, assigned_by)
+			 SELECT $1, user_id, assigned_by FROM task_assignees WHERE task_id = $2::UUID`,
+			taskID, rule.TaskID,
+		)
+		if err != nil {
+			return "", fmt.Errorf("error al copiar responsables de la ocurrencia: %w", err)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO task_watchers (task_id, user_id)
+			 SELECT $1, user_id FROM task_watchers WHERE task_id = $2::UUID`,
+			taskID, rule.TaskID,
+		)
+		if err != nil {
+			return "", fmt.Errorf("error al copiar seguidores de la ocurrencia: %w", err)
+		}
+
+		err = recordTaskEvents(ctx, tx, rule.WorkspaceID, taskID, createdBy, []taskChange{
+			{field: models.TaskEventCreated, newValue: &title},
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE task_recurrences
+		 SET last_task_id=$2, last_due_at=$3, occurrences=occurrences + 1, updated_at=CURRENT_TIMESTAMP
+		 WHERE id=$1::UUID`,
+		rule.ID, taskID, dueDate,
+	)
+	if err != nil {
+		return "", fmt.Errorf("error al avanzar regla de repetición: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error al confirmar ocurrencia: %w", err)
+	}
+
+	if !created {
+		return "", nil
+	}
+	return taskID, nil
+}
+
+// Deactivate marca la regla como terminada
+func (r *RecurrenceRepository) Deactivate(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"UPDATE task_recurrences SET active=FALSE, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("error al desactivar regla de repetición: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// RefreshTokenRepository implementa domain.RefreshTokenRepository usando PostgreSQL
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+// NewRefreshTokenRepository crea una nueva instancia de RefreshTokenRepository
+func NewRefreshTokenRepository(db *sql.DB) domain.RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create registra un nuevo refresh token
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"INSERT INTO refresh_tokens (id, user_id, family_id, expires_at) VALUES ($1, $2, $3, $4)",
+		token.ID, token.UserID, token.FamilyID, token.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error al registrar refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetByID obtiene un refresh token por su ID (jti)
+func (r *RefreshTokenRepository) GetByID(ctx context.Context, id string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	var revokedAt sql.NullTime
+	var replacedBy sql.NullString
+
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT id, user_id, family_id, expires_at, revoked_at, replaced_by, created_at FROM refresh_tokens WHERE id = $1::UUID",
+		id,
+	).Scan(
+		&token.ID, &token.UserID, &token.FamilyID, &token.ExpiresAt,
+		&revokedAt, &replacedBy, &token.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("refresh token no encontrado")
+		}
+		return nil, fmt.Errorf("error al obtener refresh token: %w", err)
+	}
+
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	if replacedBy.Valid {
+		token.ReplacedBy = &replacedBy.String
+	}
+
+	return &token, nil
+}
+
+// Rotate revoca el token oldID y registra newToken como su reemplazo en una transacción
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, oldID string, newToken *models.RefreshToken) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Solo un request puede consumir el token: el UPDATE condicional actúa como lock
+	result, err := tx.ExecContext(
+		ctx,
+		"UPDATE refresh_tokens SET revoked_at=CURRENT_TIMESTAMP, replaced_by=$2 WHERE id=$1::UUID AND revoked_at IS NULL",
+		oldID, newToken.ID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error al rotar refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al rotar refresh token: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		log.Printf("🔴 Rotate - Refresh token ya consumido: %s\n", oldID)
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO refresh_tokens (id, user_id, family_id, expires_at) VALUES ($1, $2, $3, $4)",
+		newToken.ID, newToken.UserID, newToken.FamilyID, newToken.ExpiresAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error al registrar refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error al confirmar rotación: %w", err)
+	}
+
+	return true, nil
+}
+
+// RevokeFamily revoca todos los tokens activos de una familia
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"UPDATE refresh_tokens SET revoked_at=CURRENT_TIMESTAMP WHERE family_id=$1::UUID AND revoked_at IS NULL",
+		familyID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al revocar sesión: %w", err)
+	}
+	return nil
+}
+
+// RevokeAllForUser revoca todos los tokens activos de un usuario
+func (r *RefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"UPDATE refresh_tokens SET revoked_at=CURRENT_TIMESTAMP WHERE user_id=$1::UUID AND revoked_at IS NULL",
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al revocar sesiones: %w", err)
+	}
+	return nil
+}
@@ -5,9 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
 	"github.com/taskflow/backend/internal/models"
 )
 
@@ -21,8 +25,86 @@ func NewTaskRepository(db *sql.DB) domain.TaskRepository {
 	return &TaskRepository{db: db}
 }
 
-// GetAll obtiene todas las tareas con filtros y paginación
-func (r *TaskRepository) GetAll(ctx context.Context, userID, status string, page, pageSize int) ([]models.Task, int, error) {
+// taskColumns columnas seleccionadas para construir un models.Task con scanTask. Los
+// resúmenes de subtareas y checklist son subconsultas correlacionadas con tasks.id, por
+// lo que la tabla se consulta sin alias
+const taskColumns = `id, workspace_id, project_id, parent_task_id, recurrence_id, title, description, status, priority, due_date,
+	created_by, assigned_to, assigned_by, assigned_at, started_at, completed_at, cancelled_at, created_at, updated_at,
+	deleted_at, deleted_by, version,
+	(SELECT COUNT(*) FROM tasks sub WHERE sub.parent_task_id = tasks.id AND sub.deleted_at IS NULL),
+	(SELECT COUNT(*) FROM tasks sub WHERE sub.parent_task_id = tasks.id AND sub.deleted_at IS NULL AND sub.status = 'completed'),
+	(SELECT COALESCE(ROUND(100.0 * COUNT(*) FILTER (WHERE ci.done) / NULLIF(COUNT(*), 0)), 0)::INT
+	 FROM task_checklist_items ci WHERE ci.task_id = tasks.id)`
+
+// rowScanner abstrae *sql.Row y *sql.Rows para reutilizar scanTask
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask escanea una fila con las columnas de taskColumns
+func scanTask(row rowScanner) (*models.Task, error) {
+	var task models.Task
+	var projectID, parentID, recurrenceID sql.NullString
+	var description sql.NullString
+	var dueDate sql.NullTime
+	var assignedTo, assignedBy sql.NullString
+	var assignedAt sql.NullTime
+	var startedAt, completedAt, cancelledAt, deletedAt sql.NullTime
+	var deletedBy sql.NullString
+
+	err := row.Scan(
+		&task.ID, &task.WorkspaceID, &projectID, &parentID, &recurrenceID, &task.Title, &description, &task.Status,
+		&task.Priority, &dueDate, &task.CreatedBy, &assignedTo, &assignedBy, &assignedAt,
+		&startedAt, &completedAt, &cancelledAt, &task.CreatedAt, &task.UpdatedAt, &deletedAt, &deletedBy,
+		&task.Version, &task.SubtaskCount, &task.CompletedSubtaskCount, &task.ChecklistProgress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Description = description.String
+	if projectID.Valid {
+		task.ProjectID = &projectID.String
+	}
+	if parentID.Valid {
+		task.ParentID = &parentID.String
+	}
+	if recurrenceID.Valid {
+		task.RecurrenceID = &recurrenceID.String
+	}
+	if dueDate.Valid {
+		task.DueDate = &dueDate.Time
+	}
+	if assignedTo.Valid {
+		task.AssignedTo = &assignedTo.String
+	}
+	if assignedBy.Valid {
+		task.AssignedBy = &assignedBy.String
+	}
+	if assignedAt.Valid {
+		task.AssignedAt = &assignedAt.Time
+	}
+	if startedAt.Valid {
+		task.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+	if cancelledAt.Valid {
+		task.CancelledAt = &cancelledAt.Time
+	}
+	if deletedAt.Valid {
+		task.DeletedAt = &deletedAt.Time
+	}
+	if deletedBy.Valid {
+		task.DeletedBy = &deletedBy.String
+	}
+
+	return &task, nil
+}
+
+// GetAll obtiene todas las tareas con filtros, orden y paginación
+func (r *TaskRepository) GetAll(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, page, pageSize int) ([]models.Task, int, error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			log.Printf("🔴 PANIC en GetAll: %v\n", rec)
@@ -31,47 +113,21 @@ func (r *TaskRepository) GetAll(ctx context.Context, userID, status string, page
 
 	offset := (page - 1) * pageSize
 
-	// Construir query para contar tareas
-	countQuery := "SELECT COUNT(*) FROM tasks WHERE 1=1"
-	var countArgs []interface{}
-	argIndex := 1
-
-	// Construir query para obtener tareas
-	query := `SELECT id, title, description, status, priority, due_date, created_by, assigned_to, 
-	          created_at, updated_at FROM tasks WHERE 1=1`
-	var args []interface{}
-
-	// Filtro por usuario (si existe)
-	if userID != "" {
-		countQuery += " AND (created_by = $" + fmt.Sprintf("%d", argIndex) + " OR assigned_to = $" + fmt.Sprintf("%d", argIndex) + ")"
-		query += " AND (created_by = $" + fmt.Sprintf("%d", argIndex) + " OR assigned_to = $" + fmt.Sprintf("%d", argIndex) + ")"
-		countArgs = append(countArgs, userID)
-		args = append(args, userID)
-		argIndex++
-	}
-
-	// Filtro por estado (si existe)
-	if status != "" {
-		countQuery += " AND status = $" + fmt.Sprintf("%d", argIndex)
-		query += " AND status = $" + fmt.Sprintf("%d", argIndex)
-		countArgs = append(countArgs, status)
-		args = append(args, status)
-		argIndex++
-	}
+	q := newTaskQuery(workspaceID, filter)
+	where := q.whereClause()
 
 	// Obtener total
 	var totalCount int
-	err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks"+where, q.args...).Scan(&totalCount)
 	if err != nil {
 		log.Printf("🔴 ERROR en GetAll - Count Error: %v\n", err)
 		return nil, 0, fmt.Errorf("error al contar tareas: %w", err)
 	}
 
 	// Agregar ordering, limit y offset
-	query += " ORDER BY created_at DESC LIMIT $" + fmt.Sprintf("%d", argIndex) + " OFFSET $" + fmt.Sprintf("%d", argIndex+1)
-	args = append(args, pageSize, offset)
+	query := "SELECT " + taskColumns + " FROM tasks" + where + q.orderBy(sort) + q.limit(pageSize, offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.QueryContext(ctx, query, q.args...)
 	if err != nil {
 		log.Printf("🔴 ERROR en GetAll - QueryContext Error: %v (type: %T)\n", err, err)
 		return nil, 0, fmt.Errorf("error al obtener tareas: %w", err)
@@ -81,49 +137,91 @@ func (r *TaskRepository) GetAll(ctx context.Context, userID, status string, page
 	var tasks []models.Task
 
 	for rows.Next() {
-		var task models.Task
-		var dueDate sql.NullTime
-		var assignedTo sql.NullString
-
-		err := rows.Scan(
-			&task.ID, &task.Title, &task.Description, &task.Status,
-			&task.Priority, &dueDate, &task.CreatedBy, &assignedTo,
-			&task.CreatedAt, &task.UpdatedAt,
-		)
+		task, err := scanTask(rows)
 		if err != nil {
 			log.Printf("🔴 ERROR en GetAll - Scan Error: %v (type: %T)\n", err, err)
 			return nil, 0, fmt.Errorf("error al escanear tarea: %w", err)
 		}
 
-		if dueDate.Valid {
-			task.DueDate = &dueDate.Time
-		}
-		if assignedTo.Valid {
-			task.AssignedTo = &assignedTo.String
-		}
+		tasks = append(tasks, *task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error al obtener tareas: %w", err)
+	}
 
-		tasks = append(tasks, task)
+	if err := loadTaskDetails(ctx, r.db, tasks, filter.LabelOwner); err != nil {
+		return nil, 0, err
 	}
 
 	log.Printf("✅ GetAll - Success: returned %d tasks, totalCount=%d\n", len(tasks), totalCount)
-	return tasks, totalCount, rows.Err()
+	return tasks, totalCount, nil
 }
 
-// GetByID obtiene una tarea específica
-func (r *TaskRepository) GetByID(ctx context.Context, id string) (*models.Task, error) {
-	var task models.Task
-	var dueDate sql.NullTime
-	var assignedTo sql.NullString
+// GetAfter obtiene hasta limit tareas posteriores al cursor usando keyset pagination,
+// sin OFFSET ni COUNT. Lee una fila extra para saber si hay una página siguiente
+func (r *TaskRepository) GetAfter(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, after *models.TaskCursor, limit int) ([]models.Task, *models.TaskCursor, error) {
+	if _, ok := taskSortKeys[sort.Field]; !ok {
+		sort = models.TaskSort{Field: models.TaskSortCreatedAt, Descending: true}
+	}
 
-	err := r.db.QueryRowContext(
+	q := newTaskQuery(workspaceID, filter)
+	q.after(sort, after)
+
+	query := "SELECT " + taskColumns + " FROM tasks" + q.whereClause() + q.orderBy(sort) + q.bind(" LIMIT ?", limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, q.args...)
+	if err != nil {
+		log.Printf("🔴 ERROR en GetAfter - QueryContext Error: %v (type: %T)\n", err, err)
+		return nil, nil, fmt.Errorf("error al obtener tareas: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error al escanear tarea: %w", err)
+		}
+		tasks = append(tasks, *task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error al obtener tareas: %w", err)
+	}
+
+	var next *models.TaskCursor
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		next = cursorFor(sort, &tasks[limit-1])
+	}
+
+	if err := loadTaskDetails(ctx, r.db, tasks, filter.LabelOwner); err != nil {
+		return nil, nil, err
+	}
+
+	return tasks, next, nil
+}
+
+// Count cuenta las tareas que cumplen el filtro
+func (r *TaskRepository) Count(ctx context.Context, workspaceID string, filter models.TaskFilter) (int, error) {
+	q := newTaskQuery(workspaceID, filter)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks"+q.whereClause(), q.args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("error al contar tareas: %w", err)
+	}
+
+	return total, nil
+}
+
+// GetByID obtiene una tarea específica con las etiquetas personales de viewerID
+func (r *TaskRepository) GetByID(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+	task, err := scanTask(conn(ctx, r.db).QueryRowContext(
 		ctx,
-		"SELECT id, title, description, status, priority, due_date, created_by, assigned_to, created_at, updated_at FROM tasks WHERE id = $1::UUID",
-		id,
-	).Scan(
-		&task.ID, &task.Title, &task.Description, &task.Status,
-		&task.Priority, &dueDate, &task.CreatedBy, &assignedTo,
-		&task.CreatedAt, &task.UpdatedAt,
-	)
+		"SELECT "+taskColumns+" FROM tasks WHERE id = $1::UUID AND workspace_id = $2::UUID AND deleted_at IS NULL",
+		id, workspaceID,
+	))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -132,18 +230,16 @@ func (r *TaskRepository) GetByID(ctx context.Context, id string) (*models.Task,
 		return nil, fmt.Errorf("error al obtener tarea: %w", err)
 	}
 
-	if dueDate.Valid {
-		task.DueDate = &dueDate.Time
-	}
-	if assignedTo.Valid {
-		task.AssignedTo = &assignedTo.String
+	tasks := []models.Task{*task}
+	if err := loadTaskDetails(ctx, conn(ctx, r.db), tasks, viewerID); err != nil {
+		return nil, err
 	}
 
-	return &task, nil
+	return &tasks[0], nil
 }
 
-// Create crea una nueva tarea
-func (r *TaskRepository) Create(ctx context.Context, title, description, priority string, dueDate interface{}, createdBy string) (string, error) {
+// Create crea una nueva tarea y registra el evento de creación en la misma transacción
+func (r *TaskRepository) Create(ctx context.Context, workspaceID, title, description, priority string, dueDate interface{}, projectID, parentID *string, createdBy string) (string, error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			log.Printf("🔴 PANIC en Create: %v\n", rec)
@@ -152,13 +248,18 @@ func (r *TaskRepository) Create(ctx context.Context, title, description, priorit
 
 	var taskID string
 
-	log.Printf("📝 Create - Input: title=%s, priority=%s, dueDate=%v, createdBy=%s\n", title, priority, dueDate, createdBy)
+	log.Printf("📝 Create - Input: workspaceID=%s, title=%s, priority=%s, dueDate=%v, createdBy=%s\n", workspaceID, title, priority, dueDate, createdBy)
 
-	// Insertar directamente en la tabla tasks
-	err := r.db.QueryRowContext(
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return "", fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
 		ctx,
-		"INSERT INTO tasks (title, description, priority, due_date, created_by) VALUES ($1, $2, $3, $4, $5) RETURNING id",
-		title, description, priority, dueDate, createdBy,
+		"INSERT INTO tasks (workspace_id, project_id, parent_task_id, title, description, priority, due_date, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id",
+		workspaceID, projectID, parentID, title, description, priority, dueDate, createdBy,
 	).Scan(&taskID)
 
 	if err != nil {
@@ -166,12 +267,23 @@ func (r *TaskRepository) Create(ctx context.Context, title, description, priorit
 		return "", fmt.Errorf("error al crear tarea: %w", err)
 	}
 
+	err = recordTaskEvents(ctx, tx, workspaceID, taskID, createdBy, []taskChange{
+		{field: models.TaskEventCreated, newValue: &title},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error al confirmar tarea: %w", err)
+	}
+
 	log.Printf("✅ Task created successfully: %s\n", taskID)
 	return taskID, nil
 }
 
-// Update actualiza una tarea existente
-func (r *TaskRepository) Update(ctx context.Context, id, title, description, priority string, dueDate interface{}) error {
+// Update actualiza una tarea existente y registra un evento por cada campo modificado
+func (r *TaskRepository) Update(ctx context.Context, workspaceID, id, title, description, priority string, dueDate interface{}, version int, actorID string) error {
 	defer func() {
 		if rec := recover(); rec != nil {
 			log.Printf("🔴 PANIC en Update: %v\n", rec)
@@ -185,10 +297,25 @@ func (r *TaskRepository) Update(ctx context.Context, id, title, description, pri
 
 	log.Printf("📝 Update - Input: id=%s, title=%s, priority=%s, dueDate=%v\n", id, title, priority, dueDatePtr)
 
-	result, err := r.db.ExecContext(
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockTask(ctx, tx, workspaceID, id)
+	if err != nil {
+		log.Printf("🔴 ERROR en Update - %v\n", err)
+		return err
+	}
+	if err := checkTaskVersion(current, version); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
 		ctx,
-		"UPDATE tasks SET title=$2, description=$3, priority=$4, due_date=$5, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
-		id, title, description, priority, dueDatePtr,
+		"UPDATE tasks SET title=$2, description=$3, priority=$4, due_date=$5, version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID AND workspace_id=$6::UUID",
+		id, title, description, priority, dueDatePtr, workspaceID,
 	)
 
 	if err != nil {
@@ -196,23 +323,109 @@ func (r *TaskRepository) Update(ctx context.Context, id, title, description, pri
 		return fmt.Errorf("error al actualizar tarea: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	changes := []taskChange{}
+	changes = appendChange(changes, "title", &current.Title, &title)
+	changes = appendChange(changes, "description", &current.Description, &description)
+	changes = appendChange(changes, "priority", &current.Priority, &priority)
+	changes = appendChange(changes, "due_date", formatEventTime(current.DueDate), formatEventTime(dueDatePtr))
+
+	if err := recordTaskEvents(ctx, tx, workspaceID, id, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar actualización de tarea: %w", err)
+	}
+
+	log.Printf("✅ Update - Success, changed fields: %d\n", len(changes))
+	return nil
+}
+
+// Patch aplica los campos presentes en patch y registra un evento por cada campo
+// modificado. Solo acepta columnas de models.TaskPatchFields; si ningún valor cambia
+// no escribe nada
+func (r *TaskRepository) Patch(ctx context.Context, workspaceID, id string, patch models.TaskPatch, version int, actorID string) error {
+	columns := make([]string, 0, len(patch))
+	for column := range patch {
+		if _, ok := models.TaskPatchFields[column]; !ok {
+			return errors.NewBadRequest(fmt.Sprintf("campo no modificable: %s", column))
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockTask(ctx, tx, workspaceID, id)
+	if err != nil {
+		return err
+	}
+	if err := checkTaskVersion(current, version); err != nil {
+		return err
+	}
+
+	previous := map[string]*string{
+		"title":       &current.Title,
+		"description": patchEventValue(current.Description),
+		"priority":    &current.Priority,
+		"due_date":    formatEventTime(current.DueDate),
+	}
+
+	var changes []taskChange
+	sets := make([]string, 0, len(columns))
+	args := []interface{}{id, workspaceID}
+	for _, column := range columns {
+		changes = appendChange(changes, column, previous[column], patchEventValue(patch[column]))
+		args = append(args, patch[column])
+		sets = append(sets, fmt.Sprintf("%s=$%d", column, len(args)))
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE tasks SET "+strings.Join(sets, ", ")+", version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID AND workspace_id=$2::UUID",
+		args...,
+	)
 	if err != nil {
-		log.Printf("🔴 ERROR en Update - RowsAffected Error: %v (type: %T)\n", err, err)
 		return fmt.Errorf("error al actualizar tarea: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		log.Printf("🔴 ERROR en Update - Task not found\n")
-		return fmt.Errorf("tarea no encontrada")
+	if err := recordTaskEvents(ctx, tx, workspaceID, id, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar tarea: %w", err)
 	}
 
-	log.Printf("✅ Update - Success, rows affected: %d\n", rowsAffected)
 	return nil
 }
 
-// Delete elimina una tarea
-func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+// patchEventValue representa un valor de TaskPatch como texto para el historial. La
+// descripción vacía equivale a null
+func patchEventValue(value interface{}) *string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return &v
+	case time.Time:
+		return formatEventTime(&v)
+	}
+	return nil
+}
+
+// Delete mueve una tarea a la papelera registrando quién y cuándo la eliminó. La
+// tarea se borra definitivamente con Purge al vencer la retención
+func (r *TaskRepository) Delete(ctx context.Context, workspaceID, id string, version int, actorID string) error {
 	defer func() {
 		if rec := recover(); rec != nil {
 			log.Printf("🔴 PANIC en Delete: %v\n", rec)
@@ -221,10 +434,25 @@ func (r *TaskRepository) Delete(ctx context.Context, id string) error {
 
 	log.Printf("📝 Delete - Input: id=%s\n", id)
 
-	result, err := r.db.ExecContext(
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockTask(ctx, tx, workspaceID, id)
+	if err != nil {
+		log.Printf("🔴 ERROR en Delete - %v\n", err)
+		return err
+	}
+	if err := checkTaskVersion(current, version); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
 		ctx,
-		"DELETE FROM tasks WHERE id = $1::UUID",
-		id,
+		"UPDATE tasks SET deleted_at=CURRENT_TIMESTAMP, deleted_by=$3, version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id = $1::UUID AND workspace_id = $2::UUID",
+		id, workspaceID, actorID,
 	)
 
 	if err != nil {
@@ -232,23 +460,161 @@ func (r *TaskRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("error al eliminar tarea: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	err = recordTaskEvents(ctx, tx, workspaceID, id, actorID, []taskChange{
+		{field: models.TaskEventDeleted, oldValue: &current.Title},
+	})
 	if err != nil {
-		log.Printf("🔴 ERROR en Delete - RowsAffected Error: %v (type: %T)\n", err, err)
-		return fmt.Errorf("error al eliminar tarea: %w", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación de tarea: %w", err)
+	}
+
+	log.Printf("✅ Delete - Success: %s\n", id)
+	return nil
+}
+
+// GetTrashedByID obtiene una tarea de la papelera
+func (r *TaskRepository) GetTrashedByID(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+	task, err := scanTask(conn(ctx, r.db).QueryRowContext(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE id = $1::UUID AND workspace_id = $2::UUID AND deleted_at IS NOT NULL",
+		id, workspaceID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("error al obtener tarea: %w", err)
+	}
+
+	tasks := []models.Task{*task}
+	if err := loadTaskDetails(ctx, conn(ctx, r.db), tasks, viewerID); err != nil {
+		return nil, err
+	}
+
+	return &tasks[0], nil
+}
+
+// Restore saca una tarea de la papelera y registra la restauración
+func (r *TaskRepository) Restore(ctx context.Context, workspaceID, id, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	var title string
+	err = tx.QueryRowContext(
+		ctx,
+		`UPDATE tasks SET deleted_at=NULL, deleted_by=NULL, version=version+1, updated_at=CURRENT_TIMESTAMP
+		 WHERE id = $1::UUID AND workspace_id = $2::UUID AND deleted_at IS NOT NULL
+		 RETURNING title`,
+		id, workspaceID,
+	).Scan(&title)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrTaskNotFound
+		}
+		return fmt.Errorf("error al restaurar tarea: %w", err)
+	}
+
+	err = recordTaskEvents(ctx, tx, workspaceID, id, actorID, []taskChange{
+		{field: models.TaskEventRestored, newValue: &title},
+	})
+	if err != nil {
+		return err
 	}
 
-	if rowsAffected == 0 {
-		log.Printf("🔴 ERROR en Delete - Task not found\n")
-		return fmt.Errorf("tarea no encontrada")
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar restauración de tarea: %w", err)
 	}
 
-	log.Printf("✅ Delete - Success, rows affected: %d\n", rowsAffected)
 	return nil
 }
 
-// UpdateStatus actualiza el estado de una tarea
-func (r *TaskRepository) UpdateStatus(ctx context.Context, id, status string) error {
+// Purge elimina definitivamente hasta limit tareas que están en la papelera desde
+// antes de cutoff y retorna cuántas eliminó junto con las claves de sus adjuntos,
+// que el llamador debe borrar del BlobStore. SKIP LOCKED permite varias instancias
+func (r *TaskRepository) Purge(ctx context.Context, cutoff time.Time, limit int) (int, []string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT id FROM tasks
+		 WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		 ORDER BY deleted_at ASC
+		 LIMIT $2
+		 FOR UPDATE SKIP LOCKED`,
+		cutoff, limit,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error al obtener tareas a purgar: %w", err)
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, nil, fmt.Errorf("error al escanear tarea a purgar: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("error al obtener tareas a purgar: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return 0, nil, nil
+	}
+
+	rows, err = tx.QueryContext(
+		ctx,
+		"SELECT storage_key FROM task_attachments WHERE task_id = ANY($1::UUID[])",
+		pq.Array(ids),
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error al obtener adjuntos a purgar: %w", err)
+	}
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return 0, nil, fmt.Errorf("error al escanear adjunto a purgar: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("error al obtener adjuntos a purgar: %w", err)
+	}
+
+	// Las tablas relacionadas se eliminan en cascada; task_events se conserva
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE id = ANY($1::UUID[])", pq.Array(ids)); err != nil {
+		return 0, nil, fmt.Errorf("error al purgar tareas: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("error al confirmar purga de tareas: %w", err)
+	}
+
+	return len(ids), keys, nil
+}
+
+// UpdateStatus actualiza el estado de una tarea y registra el cambio. También mantiene
+// started_at (primer paso a in_progress), completed_at y cancelled_at (se limpian al salir
+// de esos estados). Con la fila bloqueada verifica que la tarea siga en fromStatus, el
+// estado desde el que el servicio validó la transición
+func (r *TaskRepository) UpdateStatus(ctx context.Context, workspaceID, id, fromStatus, status string, version int, actorID string) error {
 	defer func() {
 		if rec := recover(); rec != nil {
 			log.Printf("🔴 PANIC en UpdateStatus: %v\n", rec)
@@ -257,10 +623,35 @@ func (r *TaskRepository) UpdateStatus(ctx context.Context, id, status string) er
 
 	log.Printf("📝 UpdateStatus - Input: id=%s, status=%s\n", id, status)
 
-	result, err := r.db.ExecContext(
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockTask(ctx, tx, workspaceID, id)
+	if err != nil {
+		log.Printf("🔴 ERROR en UpdateStatus - %v\n", err)
+		return err
+	}
+	if err := checkTaskVersion(current, version); err != nil {
+		return err
+	}
+	if current.Status != fromStatus {
+		return errors.ErrTaskStatusConflict
+	}
+
+	_, err = tx.ExecContext(
 		ctx,
-		"UPDATE tasks SET status=$2, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
-		id, status,
+		`UPDATE tasks SET
+			status = $2,
+			started_at = CASE WHEN $2 = 'in_progress' THEN COALESCE(started_at, CURRENT_TIMESTAMP) ELSE started_at END,
+			completed_at = CASE WHEN $2 = 'completed' THEN CURRENT_TIMESTAMP ELSE NULL END,
+			cancelled_at = CASE WHEN $2 = 'cancelled' THEN CURRENT_TIMESTAMP ELSE NULL END,
+			version = version + 1,
+			updated_at = CURRENT_TIMESTAMP
+		 WHERE id = $1::UUID AND workspace_id = $3::UUID`,
+		id, status, workspaceID,
 	)
 
 	if err != nil {
@@ -268,23 +659,23 @@ func (r *TaskRepository) UpdateStatus(ctx context.Context, id, status string) er
 		return fmt.Errorf("error al actualizar estado de tarea: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Printf("🔴 ERROR en UpdateStatus - RowsAffected Error: %v (type: %T)\n", err, err)
-		return fmt.Errorf("error al actualizar estado de tarea: %w", err)
+	changes := appendChange(nil, "status", &current.Status, &status)
+	if err := recordTaskEvents(ctx, tx, workspaceID, id, actorID, changes); err != nil {
+		return err
 	}
 
-	if rowsAffected == 0 {
-		log.Printf("🔴 ERROR en UpdateStatus - Task not found\n")
-		return fmt.Errorf("tarea no encontrada")
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar estado de tarea: %w", err)
 	}
 
-	log.Printf("✅ UpdateStatus - Success, rows affected: %d\n", rowsAffected)
+	log.Printf("✅ UpdateStatus - Success: %s -> %s\n", current.Status, status)
 	return nil
 }
 
-// AssignTask asigna una tarea a un usuario
-func (r *TaskRepository) AssignTask(ctx context.Context, taskID, userID string) error {
+// AssignTask reemplaza al responsable principal y registra el cambio de asignado. El
+// principal anterior deja de ser responsable; los demás se conservan. Con userID
+// vacío se quitan todos los responsables
+func (r *TaskRepository) AssignTask(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
 	defer func() {
 		if rec := recover(); rec != nil {
 			log.Printf("🔴 PANIC en AssignTask: %v\n", rec)
@@ -294,41 +685,319 @@ func (r *TaskRepository) AssignTask(ctx context.Context, taskID, userID string)
 	log.Printf("📝 AssignTask - Input: taskID=%s, userID=%s\n", taskID, userID)
 
 	// Permitir userID nil/empty para desasignar
-	var assignedTo interface{} = userID
-	if userID == "" {
-		assignedTo = nil
+	var assignedTo *string
+	if userID != "" {
+		assignedTo = &userID
+	}
+
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
 	}
+	defer tx.Rollback()
 
-	result, err := r.db.ExecContext(
+	current, err := lockTask(ctx, tx, workspaceID, taskID)
+	if err != nil {
+		log.Printf("🔴 ERROR en AssignTask - %v\n", err)
+		return err
+	}
+
+	var changes []taskChange
+
+	if assignedTo == nil {
+		rows, err := tx.QueryContext(ctx, "DELETE FROM task_assignees WHERE task_id = $1::UUID RETURNING user_id", taskID)
+		if err != nil {
+			return fmt.Errorf("error al quitar responsables: %w", err)
+		}
+		for rows.Next() {
+			var removed string
+			if err := rows.Scan(&removed); err != nil {
+				rows.Close()
+				return fmt.Errorf("error al quitar responsables: %w", err)
+			}
+			changes = appendChange(changes, "assignees", &removed, nil)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error al quitar responsables: %w", err)
+		}
+	} else {
+		if current.AssignedTo != nil && *current.AssignedTo != userID {
+			removed, err := deleteAssignee(ctx, tx, taskID, *current.AssignedTo)
+			if err != nil {
+				return err
+			}
+			if removed {
+				changes = appendChange(changes, "assignees", current.AssignedTo, nil)
+			}
+		}
+
+		added, err := insertAssignee(ctx, tx, taskID, userID, actorID)
+		if err != nil {
+			return err
+		}
+		if added {
+			changes = appendChange(changes, "assignees", nil, assignedTo)
+		}
+	}
+
+	changes = appendChange(changes, "assigned_to", current.AssignedTo, assignedTo)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := setPrimaryAssignee(ctx, tx, taskID, assignedTo, actorID); err != nil {
+		log.Printf("🔴 ERROR en AssignTask - ExecContext Error: %v (type: %T)\n", err, err)
+		return err
+	}
+	if err := recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar asignación de tarea: %w", err)
+	}
+
+	log.Printf("✅ AssignTask - Success: %s\n", taskID)
+	return nil
+}
+
+// SetParent mueve la tarea bajo otra tarea (nil la convierte en tarea raíz) y registra el cambio
+func (r *TaskRepository) SetParent(ctx context.Context, workspaceID, id string, parentID *string, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockWorkspaceGraph(ctx, tx, "task_hierarchy", workspaceID); err != nil {
+		return err
+	}
+
+	current, err := lockTask(ctx, tx, workspaceID, id)
+	if err != nil {
+		return err
+	}
+
+	// El servicio ya verificó la jerarquía, pero otro cambio pudo confirmarse antes
+	// de tomar el lock
+	if parentID != nil {
+		cycle, err := hasAncestor(ctx, tx, workspaceID, *parentID, id)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return errors.ErrTaskParentCycle
+		}
+	}
+
+	_, err = tx.ExecContext(
 		ctx,
-		"UPDATE tasks SET assigned_to=$2, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
-		taskID, assignedTo,
+		"UPDATE tasks SET parent_task_id=$2, version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID AND workspace_id=$3::UUID",
+		id, parentID, workspaceID,
 	)
+	if err != nil {
+		return fmt.Errorf("error al mover tarea: %w", err)
+	}
 
+	changes := appendChange(nil, "parent_task_id", current.ParentID, parentID)
+	if err := recordTaskEvents(ctx, tx, workspaceID, id, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar tarea padre: %w", err)
+	}
+
+	return nil
+}
+
+// HasAncestor indica si ancestorID aparece en la cadena de tareas padre de taskID
+func (r *TaskRepository) HasAncestor(ctx context.Context, workspaceID, taskID, ancestorID string) (bool, error) {
+	return hasAncestor(ctx, conn(ctx, r.db), workspaceID, taskID, ancestorID)
+}
+
+// hasAncestor implementa HasAncestor sobre la conexión o transacción indicada
+func hasAncestor(ctx context.Context, db dbtx, workspaceID, taskID, ancestorID string) (bool, error) {
+	var found bool
+
+	err := db.QueryRowContext(
+		ctx,
+		`WITH RECURSIVE ancestors(id, parent_task_id) AS (
+			SELECT id, parent_task_id FROM tasks WHERE id = $1::UUID AND workspace_id = $3::UUID
+			UNION
+			SELECT t.id, t.parent_task_id FROM tasks t
+			JOIN ancestors a ON t.id = a.parent_task_id
+		 )
+		 SELECT EXISTS (SELECT 1 FROM ancestors WHERE parent_task_id = $2::UUID)`,
+		taskID, ancestorID, workspaceID,
+	).Scan(&found)
 	if err != nil {
-		log.Printf("🔴 ERROR en AssignTask - ExecContext Error: %v (type: %T)\n", err, err)
-		return fmt.Errorf("error al asignar tarea: %w", err)
+		return false, fmt.Errorf("error al verificar jerarquía de tareas: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return found, nil
+}
+
+// CountOpenSubtasks cuenta las subtareas directas que no están completadas ni canceladas
+func (r *TaskRepository) CountOpenSubtasks(ctx context.Context, workspaceID, id string) (int, error) {
+	var count int
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM tasks WHERE parent_task_id = $1::UUID AND workspace_id = $2::UUID AND deleted_at IS NULL AND status NOT IN ('completed', 'cancelled')",
+		id, workspaceID,
+	).Scan(&count)
 	if err != nil {
-		log.Printf("🔴 ERROR en AssignTask - RowsAffected Error: %v (type: %T)\n", err, err)
-		return fmt.Errorf("error al asignar tarea: %w", err)
+		return 0, fmt.Errorf("error al contar subtareas abiertas: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		log.Printf("🔴 ERROR en AssignTask - Task not found\n")
-		return fmt.Errorf("tarea no encontrada")
+	return count, nil
+}
+
+// GetEvents obtiene el historial de cambios de una tarea paginado, del más reciente al más antiguo
+func (r *TaskRepository) GetEvents(ctx context.Context, workspaceID, taskID string, page, pageSize int) ([]models.TaskEvent, int, error) {
+	offset := (page - 1) * pageSize
+
+	var total int
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM task_events WHERE task_id = $1::UUID AND workspace_id = $2::UUID",
+		taskID, workspaceID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al contar eventos: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT e.id, e.task_id, e.actor_id, COALESCE(u.name, ''), e.field, e.old_value, e.new_value, e.created_at
+		 FROM task_events e
+		 LEFT JOIN users u ON u.id = e.actor_id
+		 WHERE e.task_id = $1::UUID AND e.workspace_id = $2::UUID
+		 ORDER BY e.created_at DESC, e.id DESC
+		 LIMIT $3 OFFSET $4`,
+		taskID, workspaceID, pageSize, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al obtener eventos: %w", err)
 	}
+	defer rows.Close()
+
+	events := []models.TaskEvent{}
+	for rows.Next() {
+		var event models.TaskEvent
+		var actorID, oldValue, newValue sql.NullString
+
+		err := rows.Scan(
+			&event.ID, &event.TaskID, &actorID, &event.ActorName, &event.Field,
+			&oldValue, &newValue, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error al escanear evento: %w", err)
+		}
 
-	log.Printf("✅ AssignTask - Success, rows affected: %d\n", rowsAffected)
+		event.ActorID = actorID.String
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		events = append(events, event)
+	}
+
+	return events, total, rows.Err()
+}
+
+// taskChange representa el cambio de un campo pendiente de registrar en task_events
+type taskChange struct {
+	field    string
+	oldValue *string
+	newValue *string
+}
+
+// appendChange agrega el cambio solo si el valor realmente cambió
+func appendChange(changes []taskChange, field string, oldValue, newValue *string) []taskChange {
+	if oldValue == nil && newValue == nil {
+		return changes
+	}
+	if oldValue != nil && newValue != nil && *oldValue == *newValue {
+		return changes
+	}
+	return append(changes, taskChange{field: field, oldValue: oldValue, newValue: newValue})
+}
+
+// formatEventTime representa una fecha opcional como texto para el historial
+func formatEventTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+// lockTask obtiene la tarea bloqueando su fila hasta el final de la transacción. Las
+// tareas en la papelera no se pueden modificar
+func lockTask(ctx context.Context, tx dbtx, workspaceID, id string) (*models.Task, error) {
+	task, err := scanTask(tx.QueryRowContext(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE id = $1::UUID AND workspace_id = $2::UUID AND deleted_at IS NULL FOR UPDATE",
+		id, workspaceID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tarea no encontrada")
+		}
+		return nil, fmt.Errorf("error al obtener tarea: %w", err)
+	}
+	return task, nil
+}
+
+// checkTaskVersion verifica la versión esperada por el cliente (If-Match) contra la
+// tarea bloqueada; 0 omite la verificación
+func checkTaskVersion(task *models.Task, version int) error {
+	if version != 0 && task.Version != version {
+		return errors.ErrTaskVersionConflict
+	}
+	return nil
+}
+
+// touchTask incrementa la versión de la tarea cuando cambian datos que se guardan
+// fuera de la tabla tasks (responsables, etiquetas)
+func touchTask(ctx context.Context, tx dbtx, taskID string) error {
+	_, err := tx.ExecContext(
+		ctx,
+		"UPDATE tasks SET version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
+		taskID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al actualizar versión de tarea: %w", err)
+	}
+	return nil
+}
+
+// recordTaskEvents inserta los cambios en task_events dentro de la transacción de la mutación
+func recordTaskEvents(ctx context.Context, tx dbtx, workspaceID, taskID, actorID string, changes []taskChange) error {
+	for _, change := range changes {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO task_events (task_id, workspace_id, actor_id, field, old_value, new_value) VALUES ($1, $2, $3, $4, $5, $6)",
+			taskID, workspaceID, actorID, change.field, change.oldValue, change.newValue,
+		)
+		if err != nil {
+			return fmt.Errorf("error al registrar evento de tarea: %w", err)
+		}
+	}
 	return nil
 }
 
-// GetStats obtiene estadísticas de tareas de un usuario
-func (r *TaskRepository) GetStats(ctx context.Context, userID string) (*models.TaskStats, error) {
+// GetStats obtiene estadísticas de las tareas que cumplen el filtro
+func (r *TaskRepository) GetStats(ctx context.Context, workspaceID string, filter models.TaskFilter) (*models.TaskStats, error) {
 	var stats models.TaskStats
 
+	q := newTaskQuery(workspaceID, filter)
+
 	err := r.db.QueryRowContext(
 		ctx,
 		`SELECT 
@@ -339,9 +1008,8 @@ func (r *TaskRepository) GetStats(ctx context.Context, userID string) (*models.T
 			COUNT(*) FILTER (WHERE status = 'cancelled'),
 			COUNT(*) FILTER (WHERE priority IN ('high', 'urgent')),
 			COUNT(*) FILTER (WHERE due_date < CURRENT_TIMESTAMP AND status != 'completed')
-		 FROM tasks
-		 WHERE created_by = $1 OR assigned_to = $1`,
-		userID,
+		 FROM tasks`+q.whereClause(),
+		q.args...,
 	).Scan(
 		&stats.TotalTasks, &stats.PendingCount, &stats.InProgressCount,
 		&stats.CompletedCount, &stats.CancelledCount, &stats.HighPriorityCount,
@@ -0,0 +1,328 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// loadTaskPeople carga en una sola consulta los responsables y seguidores de las
+// tareas indicadas, en el orden en que se agregaron
+func loadTaskPeople(ctx context.Context, db dbtx, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(tasks))
+	index := make(map[string]int, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+		index[tasks[i].ID] = i
+		tasks[i].Assignees = []string{}
+		tasks[i].Watchers = []string{}
+	}
+
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT task_id, user_id, FALSE AS watcher, created_at FROM task_assignees WHERE task_id = ANY($1::UUID[])
+		 UNION ALL
+		 SELECT task_id, user_id, TRUE AS watcher, created_at FROM task_watchers WHERE task_id = ANY($1::UUID[])
+		 ORDER BY created_at ASC, user_id ASC`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("error al obtener responsables de tareas: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, userID string
+		var watcher bool
+		var createdAt sql.NullTime
+		if err := rows.Scan(&taskID, &userID, &watcher, &createdAt); err != nil {
+			return fmt.Errorf("error al escanear responsable de tarea: %w", err)
+		}
+
+		i := index[taskID]
+		if watcher {
+			tasks[i].Watchers = append(tasks[i].Watchers, userID)
+		} else {
+			tasks[i].Assignees = append(tasks[i].Assignees, userID)
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadTaskDetails completa las tareas leídas con sus etiquetas, responsables y
+// seguidores. viewerID es el usuario cuyas etiquetas personales se cargan
+func loadTaskDetails(ctx context.Context, db dbtx, tasks []models.Task, viewerID string) error {
+	if err := loadTaskLabels(ctx, db, tasks, viewerID); err != nil {
+		return err
+	}
+	return loadTaskPeople(ctx, db, tasks)
+}
+
+// AddAssignee agrega un responsable a la tarea. Si la tarea no tenía responsable
+// principal, el nuevo pasa a serlo
+func (r *TaskRepository) AddAssignee(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockTask(ctx, tx, workspaceID, taskID)
+	if err != nil {
+		return err
+	}
+
+	added, err := insertAssignee(ctx, tx, taskID, userID, actorID)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return nil
+	}
+
+	changes := appendChange(nil, "assignees", nil, &userID)
+	if current.AssignedTo == nil {
+		if err := setPrimaryAssignee(ctx, tx, taskID, &userID, actorID); err != nil {
+			return err
+		}
+		changes = appendChange(changes, "assigned_to", nil, &userID)
+	} else if err := touchTask(ctx, tx, taskID); err != nil {
+		return err
+	}
+
+	if err := recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar responsable: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveAssignee quita un responsable de la tarea. Si era el principal, lo
+// reemplaza el responsable restante más antiguo (o ninguno)
+func (r *TaskRepository) RemoveAssignee(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockTask(ctx, tx, workspaceID, taskID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := deleteAssignee(ctx, tx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.ErrAssigneeNotFound
+	}
+
+	changes := appendChange(nil, "assignees", &userID, nil)
+	if current.AssignedTo != nil && *current.AssignedTo == userID {
+		next, err := oldestAssignee(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := setPrimaryAssignee(ctx, tx, taskID, next, actorID); err != nil {
+			return err
+		}
+		changes = appendChange(changes, "assigned_to", current.AssignedTo, next)
+	} else if err := touchTask(ctx, tx, taskID); err != nil {
+		return err
+	}
+
+	if err := recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación de responsable: %w", err)
+	}
+
+	return nil
+}
+
+// AddWatcher agrega un seguidor a la tarea y lo registra en su historial. Agregar a
+// quien ya la seguía no tiene efecto
+func (r *TaskRepository) AddWatcher(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO task_watchers (task_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		taskID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al agregar seguidor: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al agregar seguidor: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	if err := touchTask(ctx, tx, taskID); err != nil {
+		return err
+	}
+	changes := appendChange(nil, "watchers", nil, &userID)
+	if err := recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar seguidor: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveWatcher quita un seguidor de la tarea y lo registra en su historial
+func (r *TaskRepository) RemoveWatcher(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTask(ctx, tx, workspaceID, taskID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM task_watchers WHERE task_id = $1::UUID AND user_id = $2::UUID",
+		taskID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al quitar seguidor: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al quitar seguidor: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrWatcherNotFound
+	}
+
+	if err := touchTask(ctx, tx, taskID); err != nil {
+		return err
+	}
+	changes := appendChange(nil, "watchers", &userID, nil)
+	if err := recordTaskEvents(ctx, tx, workspaceID, taskID, actorID, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar eliminación de seguidor: %w", err)
+	}
+
+	return nil
+}
+
+// insertAssignee agrega el responsable e indica si no lo era ya
+func insertAssignee(ctx context.Context, tx dbtx, taskID, userID, actorID string) (bool, error) {
+	result, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO task_assignees (task_id, user_id, assigned_by) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		taskID, userID, actorID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error al agregar responsable: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al agregar responsable: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// deleteAssignee quita el responsable e indica si lo era
+func deleteAssignee(ctx context.Context, tx dbtx, taskID, userID string) (bool, error) {
+	result, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM task_assignees WHERE task_id = $1::UUID AND user_id = $2::UUID",
+		taskID, userID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error al quitar responsable: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al quitar responsable: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// oldestAssignee obtiene el responsable agregado primero, o nil si no quedan
+func oldestAssignee(ctx context.Context, tx dbtx, taskID string) (*string, error) {
+	var userID string
+	err := tx.QueryRowContext(
+		ctx,
+		"SELECT user_id FROM task_assignees WHERE task_id = $1::UUID ORDER BY created_at ASC, user_id ASC LIMIT 1",
+		taskID,
+	).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al obtener responsables: %w", err)
+	}
+
+	return &userID, nil
+}
+
+// setPrimaryAssignee actualiza tasks.assigned_to registrando quién y cuándo lo
+// fijó; sin responsable ambos quedan vacíos
+func setPrimaryAssignee(ctx context.Context, tx dbtx, taskID string, userID *string, actorID string) error {
+	var assignedBy *string
+	if userID != nil {
+		assignedBy = &actorID
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		`UPDATE tasks
+		 SET assigned_to=$2, assigned_by=$3,
+			assigned_at = CASE WHEN $2::UUID IS NULL THEN NULL ELSE CURRENT_TIMESTAMP END,
+			version=version+1, updated_at=CURRENT_TIMESTAMP
+		 WHERE id=$1::UUID`,
+		taskID, userID, assignedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("error al asignar tarea: %w", err)
+	}
+
+	return nil
+}
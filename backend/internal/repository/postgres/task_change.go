@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// taskChangesChannel canal de LISTEN/NOTIFY por el que se avisa el ID de cada evento
+const taskChangesChannel = "task_changes"
+
+// TaskChangeRepository implementa domain.TaskChangeRepository usando PostgreSQL
+type TaskChangeRepository struct {
+	db *sql.DB
+}
+
+// NewTaskChangeRepository crea una nueva instancia de TaskChangeRepository
+func NewTaskChangeRepository(db *sql.DB) domain.TaskChangeRepository {
+	return &TaskChangeRepository{db: db}
+}
+
+// taskChangeColumns columnas seleccionadas para construir un models.TaskChange con scanTaskChange
+const taskChangeColumns = "id, type, workspace_id, task_id, actor_id, task, previous_viewers, created_at"
+
+// scanTaskChange escanea una fila con las columnas de taskChangeColumns
+func scanTaskChange(row rowScanner) (*models.TaskChange, error) {
+	var change models.TaskChange
+	var task []byte
+
+	err := row.Scan(
+		&change.ID, &change.Type, &change.WorkspaceID, &change.TaskID, &change.ActorID,
+		&task, pq.Array(&change.PreviousViewers), &change.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(task, &change.Task); err != nil {
+		return nil, fmt.Errorf("error al decodificar tarea del evento: %w", err)
+	}
+
+	return &change, nil
+}
+
+// Create registra el evento y lo avisa por NOTIFY. Dentro de WithinTx se suma a la
+// transacción, así que el evento solo se publica si la operación se confirma.
+// created_at es el momento del INSERT (no el inicio de la transacción), que es cuando
+// se asigna el ID; ListAfter lo usa para encontrar los eventos confirmados tarde
+func (r *TaskChangeRepository) Create(ctx context.Context, change *models.TaskChange) error {
+	task, err := json.Marshal(change.Task)
+	if err != nil {
+		return fmt.Errorf("error al codificar tarea del evento: %w", err)
+	}
+
+	previousViewers := change.PreviousViewers
+	if previousViewers == nil {
+		previousViewers = []string{}
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`WITH inserted AS (
+			INSERT INTO task_changes (workspace_id, task_id, type, actor_id, task, previous_viewers, created_at)
+			VALUES ($1::UUID, $2::UUID, $3, $4::UUID, $5, $6::UUID[], clock_timestamp())
+			RETURNING id, created_at
+		)
+		SELECT id, created_at FROM inserted, pg_notify($7, id::TEXT)`,
+		change.WorkspaceID, change.TaskID, change.Type, change.ActorID, string(task), pq.Array(previousViewers), taskChangesChannel,
+	).Scan(&change.ID, &change.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error al registrar evento de tarea: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID obtiene un evento
+func (r *TaskChangeRepository) GetByID(ctx context.Context, id int64) (*models.TaskChange, error) {
+	change, err := scanTaskChange(r.db.QueryRowContext(
+		ctx,
+		"SELECT "+taskChangeColumns+" FROM task_changes WHERE id = $1",
+		id,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener evento de tarea %d: %w", id, err)
+	}
+
+	return change, nil
+}
+
+// ListAfter obtiene hasta limit eventos posteriores a afterID en orden de ID. Un ID
+// menor puede confirmarse después que uno mayor, así que también incluye los creados
+// hasta window antes que afterID; workspaceID vacío no filtra por workspace
+func (r *TaskChangeRepository) ListAfter(ctx context.Context, workspaceID string, afterID int64, window time.Duration, limit int) ([]models.TaskChange, error) {
+	conditions := []string{
+		`(id > $1 OR created_at >= (SELECT created_at FROM task_changes WHERE id = $1) - make_interval(secs => $2))`,
+		"id <> $1",
+	}
+	args := []interface{}{afterID, window.Seconds()}
+	if workspaceID != "" {
+		args = append(args, workspaceID)
+		conditions = append(conditions, fmt.Sprintf("workspace_id = $%d::UUID", len(args)))
+	}
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			"SELECT %s FROM task_changes WHERE %s ORDER BY id ASC LIMIT $%d",
+			taskChangeColumns, strings.Join(conditions, " AND "), len(args),
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener eventos de tareas: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []models.TaskChange{}
+	for rows.Next() {
+		change, err := scanTaskChange(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear evento de tarea: %w", err)
+		}
+		changes = append(changes, *change)
+	}
+
+	return changes, rows.Err()
+}
+
+// DeleteBefore elimina los eventos creados antes de la fecha
+func (r *TaskChangeRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM task_changes WHERE created_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("error al eliminar eventos de tareas: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error al eliminar eventos de tareas: %w", err)
+	}
+	return int(deleted), nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/domain"
+)
+
+// taskChangeListenerPing intervalo sin avisos tras el cual se verifica la conexión
+const taskChangeListenerPing = 90 * time.Second
+
+// TaskChangeListener implementa domain.TaskChangeListener con LISTEN sobre una
+// conexión dedicada que se reconecta sola
+type TaskChangeListener struct {
+	dsn string
+}
+
+// NewTaskChangeListener crea una nueva instancia de TaskChangeListener
+func NewTaskChangeListener(dsn string) domain.TaskChangeListener {
+	return &TaskChangeListener{dsn: dsn}
+}
+
+// Listen escucha el canal task_changes hasta que se cancele ctx
+func (l *TaskChangeListener) Listen(ctx context.Context, notify func(id int64)) error {
+	listener := pq.NewListener(l.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("🔴 ERROR en conexión LISTEN %s: %v\n", taskChangesChannel, err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(taskChangesChannel); err != nil {
+		return fmt.Errorf("error al escuchar %s: %w", taskChangesChannel, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// pq envía nil al reconectar: los avisos del corte se perdieron
+			if n == nil {
+				notify(0)
+				continue
+			}
+
+			id, err := strconv.ParseInt(n.Extra, 10, 64)
+			if err != nil {
+				log.Printf("🔴 ERROR aviso inválido en %s: %q\n", taskChangesChannel, n.Extra)
+				continue
+			}
+			notify(id)
+		case <-time.After(taskChangeListenerPing):
+			go listener.Ping()
+		}
+	}
+}
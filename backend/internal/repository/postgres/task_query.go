@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// taskSortKey expresión SQL de una clave de orden, cómo comparar un valor del
+// cursor contra ella y cómo obtener ese valor de una tarea ya leída
+type taskSortKey struct {
+	expression string
+	param      string
+	value      func(task *models.Task) *string
+}
+
+// taskSortKeys claves permitidas para ordenar tareas. Solo se usan expresiones de
+// esta lista; el valor recibido del cliente nunca llega al SQL
+var taskSortKeys = map[string]taskSortKey{
+	models.TaskSortCreatedAt: {
+		expression: "created_at",
+		param:      "?::TIMESTAMP",
+		value:      func(task *models.Task) *string { return formatCursorTime(&task.CreatedAt) },
+	},
+	models.TaskSortDueDate: {
+		expression: "due_date",
+		param:      "?::TIMESTAMP",
+		value:      func(task *models.Task) *string { return formatCursorTime(task.DueDate) },
+	},
+	models.TaskSortUpdatedAt: {
+		expression: "updated_at",
+		param:      "?::TIMESTAMP",
+		value:      func(task *models.Task) *string { return formatCursorTime(&task.UpdatedAt) },
+	},
+	models.TaskSortTitle: {
+		expression: "LOWER(title)",
+		param:      "LOWER(?)",
+		value:      func(task *models.Task) *string { return &task.Title },
+	},
+	models.TaskSortDeletedAt: {
+		expression: "deleted_at",
+		param:      "?::TIMESTAMP",
+		value:      func(task *models.Task) *string { return formatCursorTime(task.DeletedAt) },
+	},
+	models.TaskSortPriority: {
+		expression: "CASE priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 END",
+		param:      "?::INT",
+		value: func(task *models.Task) *string {
+			rank := strconv.Itoa(taskPriorityRank[task.Priority])
+			return &rank
+		},
+	},
+}
+
+// taskPriorityRank orden de las prioridades; debe coincidir con la expresión de taskSortKeys
+var taskPriorityRank = map[string]int{"low": 1, "medium": 2, "high": 3, "urgent": 4}
+
+// cursorTimeLayout formato de los TIMESTAMP en el cursor, con precisión de microsegundos
+const cursorTimeLayout = "2006-01-02 15:04:05.999999"
+
+// formatCursorTime representa una fecha opcional como valor de cursor
+func formatCursorTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(cursorTimeLayout)
+	return &formatted
+}
+
+// taskSearchConfig configuración de texto usada por search_vector (ver schema.sql)
+const taskSearchConfig = "simple"
+
+// taskAssignee subconsulta que indica si el usuario (?) es uno de los responsables
+// de la tarea; assigned_to siempre figura en task_assignees
+const taskAssignee = "SELECT 1 FROM task_assignees ta WHERE ta.task_id = tasks.id AND ta.user_id = ?::UUID"
+
+// taskQuery construye de forma segura las cláusulas WHERE y ORDER BY de las
+// consultas de tareas. Las condiciones usan ? como marcador y los valores viajan
+// siempre como argumentos posicionales
+type taskQuery struct {
+	conditions []string
+	args       []interface{}
+	search     string
+}
+
+// newTaskQuery crea el constructor de consulta acotado al workspace con los filtros
+// indicados. Las tareas en la papelera solo se incluyen si el filtro las pide
+func newTaskQuery(workspaceID string, filter models.TaskFilter) *taskQuery {
+	q := &taskQuery{}
+	q.where("workspace_id = ?::UUID", workspaceID)
+
+	if filter.Trashed {
+		q.where("deleted_at IS NOT NULL")
+	} else {
+		q.where("deleted_at IS NULL")
+	}
+
+	if filter.UserID != "" {
+		q.where("(created_by = ?::UUID OR EXISTS ("+taskAssignee+"))", filter.UserID, filter.UserID)
+	}
+	if len(filter.IDs) > 0 {
+		q.where("id = ANY(?::UUID[])", pq.Array(filter.IDs))
+	}
+	if filter.Status != "" {
+		q.where("status = ?", filter.Status)
+	}
+	if filter.ProjectID != "" {
+		q.where("project_id = ?::UUID", filter.ProjectID)
+	}
+	if filter.ParentID != "" {
+		q.where("parent_task_id = ?::UUID", filter.ParentID)
+	}
+	if filter.Priority != "" {
+		q.where("priority = ?", filter.Priority)
+	}
+	if filter.AssignedTo != "" {
+		q.where("EXISTS ("+taskAssignee+")", filter.AssignedTo)
+	}
+	if filter.CreatedBy != "" {
+		q.where("created_by = ?::UUID", filter.CreatedBy)
+	}
+	if filter.Unassigned {
+		q.where("assigned_to IS NULL")
+	}
+	if filter.DueFrom != nil {
+		q.where("due_date >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		q.where("due_date <= ?", *filter.DueTo)
+	}
+	if filter.Overdue {
+		q.where("due_date < CURRENT_TIMESTAMP AND status NOT IN ('completed', 'cancelled')")
+	}
+	if len(filter.Labels) > 0 {
+		q.whereLabels(filter.Labels, filter.LabelMatch, filter.LabelOwner)
+	}
+	if filter.Query != "" {
+		q.search = filter.Query
+		q.where("search_vector @@ websearch_to_tsquery('"+taskSearchConfig+"', ?)", filter.Query)
+	}
+
+	return q
+}
+
+// whereLabels filtra por nombres de etiqueta: con any basta una coincidencia, con
+// all la tarea debe tener todas. Solo cuentan las etiquetas del workspace de la tarea
+// y las personales de owner
+func (q *taskQuery) whereLabels(labels []string, match, owner string) {
+	names := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		name := strings.ToLower(label)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	const labelNames = "SELECT LOWER(l.name) FROM task_labels tl JOIN labels l ON l.id = tl.label_id" +
+		" WHERE tl.task_id = tasks.id AND LOWER(l.name) = ANY(?::TEXT[])" +
+		" AND l.workspace_id = tasks.workspace_id" +
+		" AND (l.user_id IS NULL OR l.user_id = NULLIF(?, '')::UUID)"
+
+	if match == models.LabelMatchAll {
+		q.where("(SELECT COUNT(DISTINCT names.name) FROM ("+labelNames+") AS names(name)) = ?", pq.Array(names), owner, len(names))
+		return
+	}
+
+	q.where("EXISTS ("+labelNames+")", pq.Array(names), owner)
+}
+
+// where agrega una condición reemplazando cada ? por el siguiente placeholder posicional
+func (q *taskQuery) where(condition string, args ...interface{}) {
+	q.conditions = append(q.conditions, q.bind(condition, args...))
+}
+
+// bind reemplaza los ? de la expresión por placeholders $n y registra sus argumentos
+func (q *taskQuery) bind(expression string, args ...interface{}) string {
+	var b strings.Builder
+	next := 0
+
+	for _, r := range expression {
+		if r == '?' && next < len(args) {
+			q.args = append(q.args, args[next])
+			next++
+			b.WriteString("$" + strconv.Itoa(len(q.args)))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// whereClause retorna la cláusula WHERE con todas las condiciones
+func (q *taskQuery) whereClause() string {
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// orderBy retorna la cláusula ORDER BY. Sin orden explícito, una búsqueda ordena
+// por relevancia y el resto por fecha de creación descendente. El ID desempata
+// para que la paginación sea estable
+func (q *taskQuery) orderBy(sort models.TaskSort) string {
+	key, ok := taskSortKeys[sort.Field]
+	if !ok {
+		if q.search != "" {
+			rank := q.bind("ts_rank(search_vector, websearch_to_tsquery('"+taskSearchConfig+"', ?))", q.search)
+			return " ORDER BY " + rank + " DESC, created_at DESC, id DESC"
+		}
+		return " ORDER BY created_at DESC, id DESC"
+	}
+
+	direction := " ASC"
+	if sort.Descending {
+		direction = " DESC"
+	}
+
+	return " ORDER BY " + key.expression + direction + " NULLS LAST, id" + direction
+}
+
+// after agrega la condición de keyset para continuar después del cursor. Respeta
+// NULLS LAST: tras un valor no nulo siguen los mayores (o menores), los iguales con
+// ID posterior y los nulos; tras un nulo solo los nulos con ID posterior
+func (q *taskQuery) after(sort models.TaskSort, cursor *models.TaskCursor) {
+	key, ok := taskSortKeys[sort.Field]
+	if !ok || cursor == nil {
+		return
+	}
+
+	op := " > "
+	if sort.Descending {
+		op = " < "
+	}
+
+	if cursor.Value == nil {
+		q.where("("+key.expression+" IS NULL AND id"+op+"?::UUID)", cursor.ID)
+		return
+	}
+
+	q.where(
+		"("+key.expression+op+key.param+
+			" OR ("+key.expression+" = "+key.param+" AND id"+op+"?::UUID)"+
+			" OR "+key.expression+" IS NULL)",
+		*cursor.Value, *cursor.Value, cursor.ID,
+	)
+}
+
+// cursorFor construye el cursor que apunta a la tarea indicada
+func cursorFor(sort models.TaskSort, task *models.Task) *models.TaskCursor {
+	return &models.TaskCursor{
+		Sort:       sort.Field,
+		Descending: sort.Descending,
+		Value:      taskSortKeys[sort.Field].value(task),
+		ID:         task.ID,
+	}
+}
+
+// limit agrega LIMIT y OFFSET como argumentos posicionales
+func (q *taskQuery) limit(pageSize, offset int) string {
+	return q.bind(" LIMIT ? OFFSET ?", pageSize, offset)
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/taskflow/backend/internal/domain"
+)
+
+// dbtx operaciones comunes a *sql.Tx y txScope que usan los helpers del repositorio
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txContextKey clave del contexto donde WithinTx deja la transacción en curso
+type txContextKey struct{}
+
+// Transactor implementa domain.Transactor usando PostgreSQL
+type Transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor crea una nueva instancia de Transactor
+func NewTransactor(db *sql.DB) domain.Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTx ejecuta fn en una transacción que comparten las operaciones que usan beginTx
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar transacción: %w", err)
+	}
+
+	return nil
+}
+
+// conn retorna la transacción de WithinTx si la hay; si no, la base de datos
+func conn(ctx context.Context, db *sql.DB) dbtx {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// txScope transacción de una operación del repositorio. Si la operación se ejecuta
+// dentro de WithinTx se suma a esa transacción y Commit/Rollback no tienen efecto:
+// confirmarla o revertirla le corresponde a WithinTx
+type txScope struct {
+	*sql.Tx
+	owned bool
+}
+
+// beginTx inicia la transacción de una operación o reutiliza la de WithinTx
+func beginTx(ctx context.Context, db *sql.DB) (*txScope, error) {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return &txScope{Tx: tx}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &txScope{Tx: tx, owned: true}, nil
+}
+
+// Commit confirma la transacción si la operación la inició
+func (t *txScope) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+// Rollback revierte la transacción si la operación la inició
+func (t *txScope) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// lockWorkspaceGraph serializa dentro del workspace las escrituras que deben mantener
+// un grafo sin ciclos (graph identifica cuál: jerarquía o dependencias). Dos aristas
+// que juntas cierran un ciclo se verifican así una después de la otra. El lock se
+// libera al terminar la transacción
+func lockWorkspaceGraph(ctx context.Context, tx dbtx, graph, workspaceID string) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))", graph, workspaceID); err != nil {
+		return fmt.Errorf("error al bloquear %s del workspace: %w", graph, err)
+	}
+	return nil
+}
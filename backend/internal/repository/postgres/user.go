@@ -28,9 +28,9 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 
 	err := r.db.QueryRowContext(
 		ctx,
-		"SELECT id, email, name, created_at, updated_at FROM users WHERE email = $1",
+		"SELECT id, email, name, role, created_at, updated_at FROM users WHERE email = $1",
 		email,
-	).Scan(&user.ID, &user.Email, &user.Name, &user.CreatedAt, &user.UpdatedAt)
+	).Scan(&user.ID, &user.Email, &user.Name, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -42,15 +42,36 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 	return &user, nil
 }
 
+// GetCredentialsByEmail obtiene un usuario y su hash de contraseña por email
+func (r *UserRepository) GetCredentialsByEmail(ctx context.Context, email string) (*models.User, string, error) {
+	var user models.User
+	var passwordHash string
+
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT id, email, name, role, password_hash, created_at, updated_at FROM users WHERE email = $1",
+		email,
+	).Scan(&user.ID, &user.Email, &user.Name, &user.Role, &passwordHash, &user.CreatedAt, &user.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, "", fmt.Errorf("usuario no encontrado")
+		}
+		return nil, "", fmt.Errorf("error al obtener usuario: %w", err)
+	}
+
+	return &user, passwordHash, nil
+}
+
 // GetByID obtiene un usuario por ID
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
 	var user models.User
 
-	err := r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
-		"SELECT id, email, name, created_at, updated_at FROM users WHERE id = $1::UUID",
+		"SELECT id, email, name, role, created_at, updated_at FROM users WHERE id = $1::UUID",
 		id,
-	).Scan(&user.ID, &user.Email, &user.Name, &user.CreatedAt, &user.UpdatedAt)
+	).Scan(&user.ID, &user.Email, &user.Name, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -88,6 +109,29 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	return nil
 }
 
+// UpdateRole actualiza el rol de un usuario
+func (r *UserRepository) UpdateRole(ctx context.Context, id, role string) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE users SET role=$2, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
+		id, role,
+	)
+	if err != nil {
+		return fmt.Errorf("error al actualizar rol: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al actualizar rol: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrUserNotFound
+	}
+
+	return nil
+}
+
 // GetAllUsers obtiene todos los usuarios registrados en el sistema
 func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error) {
 	defer func() {
@@ -100,7 +144,7 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error
 
 	rows, err := r.db.QueryContext(
 		ctx,
-		`SELECT id, email, name, created_at, updated_at 
+		`SELECT id, email, name, role, created_at, updated_at 
 		 FROM users 
 		 ORDER BY created_at DESC`,
 	)
@@ -118,6 +162,7 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error
 			&user.ID,
 			&user.Email,
 			&user.Name,
+			&user.Role,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
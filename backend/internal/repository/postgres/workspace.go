@@ -0,0 +1,371 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// WorkspaceRepository implementa domain.WorkspaceRepository usando PostgreSQL
+type WorkspaceRepository struct {
+	db *sql.DB
+}
+
+// NewWorkspaceRepository crea una nueva instancia de WorkspaceRepository
+func NewWorkspaceRepository(db *sql.DB) domain.WorkspaceRepository {
+	return &WorkspaceRepository{db: db}
+}
+
+// Create crea un workspace y registra al dueño como miembro en una transacción
+func (r *WorkspaceRepository) Create(ctx context.Context, name, description, ownerID string) (string, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return "", fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	workspaceID, err := createWorkspace(ctx, tx, name, description, ownerID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error al confirmar workspace: %w", err)
+	}
+
+	log.Printf("✅ Workspace created successfully: %s\n", workspaceID)
+	return workspaceID, nil
+}
+
+// EnsureDefaultMembership retorna la membresía más antigua del usuario y, si no es
+// miembro de ningún workspace, crea antes uno con ese nombre. Un advisory lock por
+// usuario serializa los requests concurrentes, así que se crea uno solo
+func (r *WorkspaceRepository) EnsureDefaultMembership(ctx context.Context, userID, name string) (*models.WorkspaceMember, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("error al iniciar transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('default_workspace:' || $1))", userID); err != nil {
+		return nil, fmt.Errorf("error al bloquear workspace por defecto: %w", err)
+	}
+
+	member, err := scanMember(tx.QueryRowContext(ctx, defaultMembershipQuery, userID))
+	if err == errors.ErrMemberNotFound {
+		workspaceID, err := createWorkspace(ctx, tx, name, "", userID)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("✅ Workspace created successfully: %s\n", workspaceID)
+		member, err = scanMember(tx.QueryRowContext(ctx, defaultMembershipQuery, userID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error al confirmar workspace: %w", err)
+	}
+
+	return member, nil
+}
+
+// createWorkspace inserta el workspace y registra al dueño como miembro
+func createWorkspace(ctx context.Context, tx dbtx, name, description, ownerID string) (string, error) {
+	var workspaceID string
+	err := tx.QueryRowContext(
+		ctx,
+		"INSERT INTO workspaces (name, description, owner_id) VALUES ($1, $2, $3) RETURNING id",
+		name, description, ownerID,
+	).Scan(&workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("error al crear workspace: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)",
+		workspaceID, ownerID, models.WorkspaceRoleOwner,
+	)
+	if err != nil {
+		return "", fmt.Errorf("error al registrar dueño del workspace: %w", err)
+	}
+
+	return workspaceID, nil
+}
+
+// GetByID obtiene un workspace por ID
+func (r *WorkspaceRepository) GetByID(ctx context.Context, id string) (*models.Workspace, error) {
+	var workspace models.Workspace
+	var description sql.NullString
+
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT id, name, description, owner_id, created_at, updated_at FROM workspaces WHERE id = $1::UUID",
+		id,
+	).Scan(&workspace.ID, &workspace.Name, &description, &workspace.OwnerID, &workspace.CreatedAt, &workspace.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("workspace no encontrado")
+		}
+		return nil, fmt.Errorf("error al obtener workspace: %w", err)
+	}
+
+	workspace.Description = description.String
+	return &workspace, nil
+}
+
+// ListForUser obtiene los workspaces a los que pertenece un usuario
+func (r *WorkspaceRepository) ListForUser(ctx context.Context, userID string) ([]models.Workspace, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT w.id, w.name, w.description, w.owner_id, w.created_at, w.updated_at
+		 FROM workspaces w
+		 JOIN workspace_members m ON m.workspace_id = w.id
+		 WHERE m.user_id = $1::UUID
+		 ORDER BY m.created_at ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	workspaces := []models.Workspace{}
+	for rows.Next() {
+		var workspace models.Workspace
+		var description sql.NullString
+		if err := rows.Scan(&workspace.ID, &workspace.Name, &description, &workspace.OwnerID, &workspace.CreatedAt, &workspace.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error al escanear workspace: %w", err)
+		}
+		workspace.Description = description.String
+		workspaces = append(workspaces, workspace)
+	}
+
+	return workspaces, rows.Err()
+}
+
+// Update actualiza nombre y descripción de un workspace
+func (r *WorkspaceRepository) Update(ctx context.Context, id, name, description string) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE workspaces SET name=$2, description=$3, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
+		id, name, description,
+	)
+	if err != nil {
+		return fmt.Errorf("error al actualizar workspace: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al actualizar workspace: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrWorkspaceNotFound
+	}
+
+	return nil
+}
+
+// Delete elimina un workspace; sus tareas y membresías se eliminan en cascada
+func (r *WorkspaceRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM workspaces WHERE id = $1::UUID", id)
+	if err != nil {
+		return fmt.Errorf("error al eliminar workspace: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al eliminar workspace: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrWorkspaceNotFound
+	}
+
+	return nil
+}
+
+// GetMember obtiene la membresía de un usuario en un workspace
+func (r *WorkspaceRepository) GetMember(ctx context.Context, workspaceID, userID string) (*models.WorkspaceMember, error) {
+	return r.getMember(ctx,
+		`SELECT m.workspace_id, m.user_id, u.email, u.name, m.role, m.created_at
+		 FROM workspace_members m
+		 JOIN users u ON u.id = m.user_id
+		 WHERE m.workspace_id = $1::UUID AND m.user_id = $2::UUID`,
+		workspaceID, userID,
+	)
+}
+
+// defaultMembershipQuery consulta la membresía más antigua de un usuario
+const defaultMembershipQuery = `SELECT m.workspace_id, m.user_id, u.email, u.name, m.role, m.created_at
+	 FROM workspace_members m
+	 JOIN users u ON u.id = m.user_id
+	 WHERE m.user_id = $1::UUID
+	 ORDER BY m.created_at ASC
+	 LIMIT 1`
+
+// GetDefaultMembership obtiene la membresía más antigua de un usuario
+func (r *WorkspaceRepository) GetDefaultMembership(ctx context.Context, userID string) (*models.WorkspaceMember, error) {
+	return r.getMember(ctx, defaultMembershipQuery, userID)
+}
+
+// getMember ejecuta una consulta que retorna una sola membresía
+func (r *WorkspaceRepository) getMember(ctx context.Context, query string, args ...interface{}) (*models.WorkspaceMember, error) {
+	return scanMember(conn(ctx, r.db).QueryRowContext(ctx, query, args...))
+}
+
+// scanMember escanea una membresía. Sin filas retorna errors.ErrMemberNotFound, que
+// se distingue de un error de la base de datos
+func scanMember(row rowScanner) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+
+	err := row.Scan(
+		&member.WorkspaceID, &member.UserID, &member.Email, &member.Name, &member.Role, &member.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("error al obtener membresía: %w", err)
+	}
+
+	return &member, nil
+}
+
+// ListMembers obtiene los miembros de un workspace
+func (r *WorkspaceRepository) ListMembers(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT m.workspace_id, m.user_id, u.email, u.name, m.role, m.created_at
+		 FROM workspace_members m
+		 JOIN users u ON u.id = m.user_id
+		 WHERE m.workspace_id = $1::UUID
+		 ORDER BY m.created_at ASC`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener miembros: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.WorkspaceMember{}
+	for rows.Next() {
+		var member models.WorkspaceMember
+		if err := rows.Scan(&member.WorkspaceID, &member.UserID, &member.Email, &member.Name, &member.Role, &member.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error al escanear miembro: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// AddMember agrega un usuario a un workspace con el rol indicado
+func (r *WorkspaceRepository) AddMember(ctx context.Context, workspaceID, userID, role string) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)",
+		workspaceID, userID, role,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return errors.ErrAlreadyMember
+		}
+		return fmt.Errorf("error al agregar miembro: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember elimina a un usuario de un workspace
+func (r *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM workspace_members WHERE workspace_id = $1::UUID AND user_id = $2::UUID",
+		workspaceID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("error al eliminar miembro: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al eliminar miembro: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// GetStatusTransitions obtiene las transiciones de estado personalizadas del workspace
+func (r *WorkspaceRepository) GetStatusTransitions(ctx context.Context, workspaceID string) (map[string][]string, error) {
+	var raw []byte
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		"SELECT status_transitions FROM workspaces WHERE id = $1::UUID",
+		workspaceID,
+	).Scan(&raw)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrWorkspaceNotFound
+		}
+		return nil, fmt.Errorf("error al obtener transiciones de estado: %w", err)
+	}
+
+	if raw == nil {
+		return nil, nil
+	}
+
+	var transitions map[string][]string
+	if err := json.Unmarshal(raw, &transitions); err != nil {
+		return nil, fmt.Errorf("error al decodificar transiciones de estado: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// SetStatusTransitions guarda las transiciones de estado personalizadas; nil restablece las por defecto
+func (r *WorkspaceRepository) SetStatusTransitions(ctx context.Context, workspaceID string, transitions map[string][]string) error {
+	var raw interface{}
+	if transitions != nil {
+		encoded, err := json.Marshal(transitions)
+		if err != nil {
+			return fmt.Errorf("error al codificar transiciones de estado: %w", err)
+		}
+		raw = string(encoded)
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE workspaces SET status_transitions=$2::JSONB, updated_at=CURRENT_TIMESTAMP WHERE id=$1::UUID",
+		workspaceID, raw,
+	)
+	if err != nil {
+		return fmt.Errorf("error al guardar transiciones de estado: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al guardar transiciones de estado: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.ErrWorkspaceNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// maxAttachmentNameLength longitud máxima del nombre de un adjunto
+const maxAttachmentNameLength = 255
+
+// AttachmentService maneja la lógica de negocio de los archivos adjuntos de tareas
+type AttachmentService struct {
+	attachmentRepo domain.AttachmentRepository
+	taskRepo       domain.TaskRepository
+	blobStore      domain.BlobStore
+	maxSize        int64
+}
+
+// NewAttachmentService crea una nueva instancia de AttachmentService. maxSize es el
+// tamaño máximo por archivo en bytes
+func NewAttachmentService(attachmentRepo domain.AttachmentRepository, taskRepo domain.TaskRepository, blobStore domain.BlobStore, maxSize int64) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		blobStore:      blobStore,
+		maxSize:        maxSize,
+	}
+}
+
+// MaxSize retorna el tamaño máximo por archivo en bytes
+func (s *AttachmentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// ListAttachments obtiene los adjuntos de una tarea visible para el actor
+func (s *AttachmentService) ListAttachments(ctx context.Context, taskID string, actor *models.Actor) ([]models.Attachment, error) {
+	if err := s.authorize(ctx, taskID, actor, TaskActionView); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener adjuntos: %v", err))
+	}
+
+	return attachments, nil
+}
+
+// Upload guarda un archivo y lo adjunta a la tarea (requiere poder editarla). El
+// tipo MIME se detecta a partir del contenido y se registra su SHA-256
+func (s *AttachmentService) Upload(ctx context.Context, taskID, fileName string, content io.Reader, actor *models.Actor) (*models.Attachment, error) {
+	if err := s.authorize(ctx, taskID, actor, TaskActionUpdate); err != nil {
+		return nil, err
+	}
+
+	name := sanitizeFileName(fileName)
+	if name == "" {
+		return nil, errors.NewBadRequest("El nombre del archivo es inválido")
+	}
+
+	// Se lee un byte más del límite para distinguir un archivo demasiado grande
+	data, err := io.ReadAll(io.LimitReader(content, s.maxSize+1))
+	if err != nil {
+		return nil, errors.NewBadRequest("No se pudo leer el archivo")
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, errors.NewAppError(413, "El archivo excede el tamaño máximo permitido", fmt.Sprintf("máximo %d bytes", s.maxSize))
+	}
+	if len(data) == 0 {
+		return nil, errors.NewBadRequest("El archivo está vacío")
+	}
+
+	checksum := sha256.Sum256(data)
+	attachmentID := uuid.New().String()
+	uploadedBy := actor.UserID
+
+	attachment := &models.Attachment{
+		ID:          attachmentID,
+		TaskID:      taskID,
+		FileName:    name,
+		ContentType: http.DetectContentType(data),
+		Size:        int64(len(data)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+		StorageKey:  actor.WorkspaceID + "/" + taskID + "/" + attachmentID,
+		UploadedBy:  &uploadedBy,
+	}
+
+	if err := s.blobStore.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.Size, attachment.ContentType); err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al guardar archivo: %v", err))
+	}
+
+	if err := s.attachmentRepo.Create(ctx, actor.WorkspaceID, attachment); err != nil {
+		// Sin registro el archivo quedaría huérfano
+		if delErr := s.blobStore.Delete(ctx, attachment.StorageKey); delErr != nil {
+			log.Printf("error al eliminar archivo huérfano %s: %v", attachment.StorageKey, delErr)
+		}
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al crear adjunto: %v", err))
+	}
+
+	attachment, err = s.attachmentRepo.GetByID(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener adjunto: %v", err))
+	}
+
+	return attachment, nil
+}
+
+// Download obtiene los metadatos y el contenido de un adjunto; el llamador debe cerrar el contenido
+func (s *AttachmentService) Download(ctx context.Context, taskID, attachmentID string, actor *models.Actor) (*models.Attachment, io.ReadCloser, error) {
+	if err := s.authorize(ctx, taskID, actor, TaskActionView); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, errors.ErrAttachmentNotFound
+	}
+
+	content, err := s.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if err == domain.ErrBlobNotFound {
+			return nil, nil, errors.ErrAttachmentNotFound
+		}
+		return nil, nil, errors.NewInternalServerError(fmt.Sprintf("error al leer archivo: %v", err))
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment elimina un adjunto (requiere poder editar la tarea). Si falla el
+// borrado del archivo el registro ya no existe y solo se anota en el log
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID, attachmentID string, actor *models.Actor) error {
+	if err := s.authorize(ctx, taskID, actor, TaskActionUpdate); err != nil {
+		return err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(ctx, taskID, attachmentID)
+	if err != nil {
+		return errors.ErrAttachmentNotFound
+	}
+
+	if err := s.attachmentRepo.Delete(ctx, actor.WorkspaceID, taskID, attachmentID, actor.UserID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar adjunto: %v", err))
+	}
+
+	if err := s.blobStore.Delete(ctx, attachment.StorageKey); err != nil {
+		log.Printf("error al eliminar archivo %s: %v", attachment.StorageKey, err)
+	}
+
+	return nil
+}
+
+// authorize verifica que la tarea exista en el workspace y que el actor pueda realizar la acción
+func (s *AttachmentService) authorize(ctx context.Context, taskID string, actor *models.Actor, action TaskAction) error {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return errors.ErrTaskNotFound
+	}
+
+	return authorizeTask(task, actor, action)
+}
+
+// sanitizeFileName conserva solo el nombre base del archivo, sin caracteres de
+// control y con una longitud acotada
+func sanitizeFileName(fileName string) string {
+	name := filepath.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if name == "." || name == "/" || name == ".." {
+		return ""
+	}
+
+	for len(name) > maxAttachmentNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return name
+}
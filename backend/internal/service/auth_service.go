@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/taskflow/backend/internal/domain"
 	"github.com/taskflow/backend/internal/errors"
 	"github.com/taskflow/backend/internal/models"
@@ -13,15 +16,17 @@ import (
 
 // AuthService maneja la lógica de autenticación
 type AuthService struct {
-	userRepo   domain.UserRepository
-	jwtManager *jwt.Manager
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	jwtManager       *jwt.Manager
 }
 
 // NewAuthService crea una nueva instancia de AuthService
-func NewAuthService(userRepo domain.UserRepository, jwtManager *jwt.Manager) *AuthService {
+func NewAuthService(userRepo domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository, jwtManager *jwt.Manager) *AuthService {
 	return &AuthService{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		jwtManager:       jwtManager,
 	}
 }
 
@@ -59,41 +64,48 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 
 // Login autentica a un usuario y retorna tokens
 func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.LoginResponse, error) {
-	// Obtener usuario por email
-	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	// Obtener usuario y hash de contraseña por email
+	user, passwordHash, err := s.userRepo.GetCredentialsByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, errors.ErrInvalidCredentials
 	}
 
-	// Obtener el hash de contraseña desde la BD
-	// Nota: Necesitaremos una función en el repositorio para obtener el hash
-	// Por ahora asumimos que comparamos con lo que tenemos
-	// En producción, se debería obtener el hash guardado
+	// Verificar contraseña contra el hash guardado
+	if !password.ComparePassword(passwordHash, req.Password) {
+		return nil, errors.ErrInvalidCredentials
+	}
+
+	// Generar tokens iniciando una nueva familia (sesión)
+	return s.issueTokens(ctx, user, uuid.NewString(), "")
+}
 
-	// Generar tokens
-	accessToken, err := s.jwtManager.GenerateToken(user.ID, user.Email)
+// RefreshToken rota un refresh token y genera un nuevo par de tokens.
+// Si se presenta un token ya rotado se revoca toda la sesión
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.LoginResponse, error) {
+	// Validar refresh token (se rechazan access tokens)
+	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
 	if err != nil {
-		return nil, errors.NewInternalServerError(fmt.Sprintf("error al generar token: %v", err))
+		return nil, errors.ErrInvalidToken
 	}
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, user.Email)
-	if err != nil {
-		return nil, errors.NewInternalServerError(fmt.Sprintf("error al generar refresh token: %v", err))
+	// Obtener el token almacenado
+	stored, err := s.refreshTokenRepo.GetByID(ctx, claims.ID)
+	if err != nil || stored.UserID != claims.UserID {
+		return nil, errors.ErrInvalidToken
 	}
 
-	return &models.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    3600,
-		User:         *user,
-	}, nil
-}
+	if stored.RevokedAt != nil {
+		// Un token ya rotado que vuelve a presentarse indica robo: revocar la familia completa
+		if stored.ReplacedBy != nil {
+			log.Printf("🔴 RefreshToken - Reutilización detectada, revocando familia %s\n", stored.FamilyID)
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+				return nil, errors.NewInternalServerError(fmt.Sprintf("error al revocar sesión: %v", err))
+			}
+		}
+		return nil, errors.ErrInvalidToken
+	}
 
-// RefreshToken genera un nuevo access token usando un refresh token
-func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.LoginResponse, error) {
-	// Validar refresh token
-	claims, err := s.jwtManager.ValidateToken(refreshToken)
-	if err != nil {
+	if time.Now().After(stored.ExpiresAt) {
 		return nil, errors.ErrInvalidToken
 	}
 
@@ -103,21 +115,77 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*m
 		return nil, errors.ErrUserNotFound
 	}
 
-	// Generar nuevo access token
-	accessToken, err := s.jwtManager.GenerateToken(user.ID, user.Email)
+	return s.issueTokens(ctx, user, stored.FamilyID, stored.ID)
+}
+
+// Logout revoca la sesión (familia) a la que pertenece el refresh token
+func (s *AuthService) Logout(ctx context.Context, userID, refreshToken string) error {
+	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return errors.ErrInvalidToken
+	}
+
+	stored, err := s.refreshTokenRepo.GetByID(ctx, claims.ID)
+	if err != nil || stored.UserID != userID {
+		return errors.ErrInvalidToken
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return errors.NewInternalServerError(fmt.Sprintf("error al cerrar sesión: %v", err))
+	}
+
+	return nil
+}
+
+// LogoutAll revoca todas las sesiones del usuario
+func (s *AuthService) LogoutAll(ctx context.Context, userID string) error {
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return errors.NewInternalServerError(fmt.Sprintf("error al cerrar sesiones: %v", err))
+	}
+	return nil
+}
+
+// issueTokens genera un access token y un refresh token dentro de la familia indicada.
+// Si previousID no está vacío, el refresh token anterior se rota de forma atómica
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, familyID, previousID string) (*models.LoginResponse, error) {
+	accessToken, err := s.jwtManager.GenerateToken(user.ID, user.Email, user.Role)
 	if err != nil {
 		return nil, errors.NewInternalServerError(fmt.Sprintf("error al generar token: %v", err))
 	}
 
-	// Generar nuevo refresh token
-	newRefreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, user.Email)
+	stored := &models.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(s.jwtManager.RefreshTokenTTL()),
+	}
+
+	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, user.Email, stored.ID)
 	if err != nil {
 		return nil, errors.NewInternalServerError(fmt.Sprintf("error al generar refresh token: %v", err))
 	}
 
+	if previousID == "" {
+		if err := s.refreshTokenRepo.Create(ctx, stored); err != nil {
+			return nil, errors.NewInternalServerError(fmt.Sprintf("error al registrar refresh token: %v", err))
+		}
+	} else {
+		rotated, err := s.refreshTokenRepo.Rotate(ctx, previousID, stored)
+		if err != nil {
+			return nil, errors.NewInternalServerError(fmt.Sprintf("error al rotar refresh token: %v", err))
+		}
+		if !rotated {
+			// Otro request consumió el token primero: tratarlo como reutilización
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, familyID); err != nil {
+				return nil, errors.NewInternalServerError(fmt.Sprintf("error al revocar sesión: %v", err))
+			}
+			return nil, errors.ErrInvalidToken
+		}
+	}
+
 	return &models.LoginResponse{
 		AccessToken:  accessToken,
-		RefreshToken: newRefreshToken,
+		RefreshToken: refreshToken,
 		ExpiresIn:    3600,
 		User:         *user,
 	}, nil
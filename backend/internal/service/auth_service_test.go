@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/utils/jwt"
+	"github.com/taskflow/backend/internal/utils/password"
+)
+
+func TestAuthServiceLogin(t *testing.T) {
+	hash, err := password.HashPassword("correcta123")
+	if err != nil {
+		t.Fatalf("error al hashear contraseña: %v", err)
+	}
+
+	user := &models.User{ID: "user-1", Email: "ana@example.com", Name: "Ana", Role: models.RoleMember}
+	userRepo := &MockUserRepository{
+		GetCredentialsByEmailFunc: func(ctx context.Context, email string) (*models.User, string, error) {
+			if email != user.Email {
+				return nil, "", errors.ErrUserNotFound
+			}
+			return user, hash, nil
+		},
+	}
+
+	var stored *models.RefreshToken
+	refreshTokenRepo := &MockRefreshTokenRepository{
+		CreateFunc: func(ctx context.Context, token *models.RefreshToken) error {
+			stored = token
+			return nil
+		},
+	}
+
+	jwtManager := jwt.NewManager("secreto-de-prueba", 3600, 604800)
+	svc := NewAuthService(userRepo, refreshTokenRepo, jwtManager)
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		wantErr  error
+	}{
+		{name: "contraseña correcta", email: "ana@example.com", password: "correcta123"},
+		{name: "contraseña incorrecta", email: "ana@example.com", password: "incorrecta", wantErr: errors.ErrInvalidCredentials},
+		{name: "email desconocido", email: "nadie@example.com", password: "correcta123", wantErr: errors.ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored = nil
+
+			resp, err := svc.Login(context.Background(), &models.LoginRequest{Email: tt.email, Password: tt.password})
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+				}
+				if resp != nil || stored != nil {
+					t.Fatal("no debe emitir tokens con credenciales inválidas")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if resp.User.ID != user.ID {
+				t.Errorf("usuario = %s, se esperaba %s", resp.User.ID, user.ID)
+			}
+			if _, err := jwtManager.ValidateAccessToken(resp.AccessToken); err != nil {
+				t.Errorf("access token inválido: %v", err)
+			}
+			claims, err := jwtManager.ValidateRefreshToken(resp.RefreshToken)
+			if err != nil {
+				t.Fatalf("refresh token inválido: %v", err)
+			}
+			if stored == nil || stored.ID != claims.ID || stored.UserID != user.ID {
+				t.Errorf("el refresh token registrado no coincide con el emitido")
+			}
+		})
+	}
+}
+
+// tokenStore refresh tokens en memoria que imitan al repositorio: Rotate solo
+// consume un token vigente y RevokeFamily revoca los vigentes de la familia
+type tokenStore struct {
+	tokens        map[string]*models.RefreshToken
+	familyRevokes []string
+	userRevokes   []string
+}
+
+func newTokenStore() *tokenStore {
+	return &tokenStore{tokens: map[string]*models.RefreshToken{}}
+}
+
+func (st *tokenStore) repo() *MockRefreshTokenRepository {
+	return &MockRefreshTokenRepository{
+		CreateFunc: func(ctx context.Context, token *models.RefreshToken) error {
+			copied := *token
+			st.tokens[token.ID] = &copied
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*models.RefreshToken, error) {
+			token, ok := st.tokens[id]
+			if !ok {
+				return nil, errors.ErrInvalidToken
+			}
+			copied := *token
+			return &copied, nil
+		},
+		RotateFunc: func(ctx context.Context, oldID string, newToken *models.RefreshToken) (bool, error) {
+			old, ok := st.tokens[oldID]
+			if !ok || old.RevokedAt != nil {
+				return false, nil
+			}
+			now := time.Now()
+			old.RevokedAt = &now
+			old.ReplacedBy = &newToken.ID
+			copied := *newToken
+			st.tokens[newToken.ID] = &copied
+			return true, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, familyID string) error {
+			st.familyRevokes = append(st.familyRevokes, familyID)
+			now := time.Now()
+			for _, token := range st.tokens {
+				if token.FamilyID == familyID && token.RevokedAt == nil {
+					token.RevokedAt = &now
+				}
+			}
+			return nil
+		},
+		RevokeAllForUserFunc: func(ctx context.Context, userID string) error {
+			st.userRevokes = append(st.userRevokes, userID)
+			return nil
+		},
+	}
+}
+
+// active cantidad de tokens vigentes de la familia
+func (st *tokenStore) active(familyID string) int {
+	n := 0
+	for _, token := range st.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// refreshFixture arma AuthService sobre un tokenStore con un usuario fijo
+func refreshFixture() (*AuthService, *tokenStore, *jwt.Manager, *models.User) {
+	user := &models.User{ID: "user-1", Email: "ana@example.com", Name: "Ana", Role: models.RoleMember}
+	userRepo := &MockUserRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.User, error) {
+			if id != user.ID {
+				return nil, errors.ErrUserNotFound
+			}
+			return user, nil
+		},
+	}
+	st := newTokenStore()
+	jwtManager := jwt.NewManager("secreto-de-prueba", 3600, 604800)
+	return NewAuthService(userRepo, st.repo(), jwtManager), st, jwtManager, user
+}
+
+// storeToken registra un refresh token y retorna el JWT que lo representa
+func storeToken(t *testing.T, st *tokenStore, jwtManager *jwt.Manager, user *models.User, token models.RefreshToken) string {
+	t.Helper()
+	st.tokens[token.ID] = &token
+	signed, err := jwtManager.GenerateRefreshToken(user.ID, user.Email, token.ID)
+	if err != nil {
+		t.Fatalf("error al generar refresh token: %v", err)
+	}
+	return signed
+}
+
+func TestAuthServiceRefreshTokenIsSingleUse(t *testing.T) {
+	svc, st, jwtManager, user := refreshFixture()
+	first := storeToken(t, st, jwtManager, user, models.RefreshToken{
+		ID: "token-1", UserID: user.ID, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour),
+	})
+
+	resp, err := svc.RefreshToken(context.Background(), first)
+	if err != nil {
+		t.Fatalf("RefreshToken: error inesperado %v", err)
+	}
+
+	claims, err := jwtManager.ValidateRefreshToken(resp.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh token rotado inválido: %v", err)
+	}
+	rotated := st.tokens[claims.ID]
+	if rotated == nil || rotated.FamilyID != "family-1" || rotated.RevokedAt != nil {
+		t.Fatalf("token rotado = %+v, se esperaba vigente en family-1", rotated)
+	}
+	if old := st.tokens["token-1"]; old.RevokedAt == nil || old.ReplacedBy == nil || *old.ReplacedBy != claims.ID {
+		t.Errorf("token anterior = %+v, se esperaba revocado y reemplazado por %s", old, claims.ID)
+	}
+
+	// El nuevo token sigue funcionando una vez
+	if _, err := svc.RefreshToken(context.Background(), resp.RefreshToken); err != nil {
+		t.Errorf("el token rotado debe poder usarse: %v", err)
+	}
+}
+
+func TestAuthServiceRefreshTokenReuseRevokesFamily(t *testing.T) {
+	svc, st, jwtManager, user := refreshFixture()
+	first := storeToken(t, st, jwtManager, user, models.RefreshToken{
+		ID: "token-1", UserID: user.ID, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour),
+	})
+
+	resp, err := svc.RefreshToken(context.Background(), first)
+	if err != nil {
+		t.Fatalf("RefreshToken: error inesperado %v", err)
+	}
+
+	// Presentar de nuevo el token ya rotado revoca toda la sesión
+	if _, err := svc.RefreshToken(context.Background(), first); err != errors.ErrInvalidToken {
+		t.Fatalf("error = %v, se esperaba ErrInvalidToken", err)
+	}
+	if len(st.familyRevokes) != 1 || st.familyRevokes[0] != "family-1" {
+		t.Errorf("familias revocadas = %v, se esperaba [family-1]", st.familyRevokes)
+	}
+	if n := st.active("family-1"); n != 0 {
+		t.Errorf("tokens vigentes en family-1 = %d, se esperaba 0", n)
+	}
+
+	// El token que recibió el atacante o el usuario legítimo tampoco sirve ya
+	if _, err := svc.RefreshToken(context.Background(), resp.RefreshToken); err != errors.ErrInvalidToken {
+		t.Errorf("error con el token rotado = %v, se esperaba ErrInvalidToken", err)
+	}
+}
+
+func TestAuthServiceRefreshTokenRejectsInvalidStoredTokens(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name             string
+		token            models.RefreshToken
+		rotateFails      bool
+		wantFamilyRevoke bool
+	}{
+		{
+			name:  "vencido",
+			token: models.RefreshToken{ExpiresAt: time.Now().Add(-time.Minute)},
+		},
+		{
+			name:  "revocado por logout",
+			token: models.RefreshToken{ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+		},
+		{
+			name:             "consumido por un request concurrente",
+			token:            models.RefreshToken{ExpiresAt: time.Now().Add(time.Hour)},
+			rotateFails:      true,
+			wantFamilyRevoke: true,
+		},
+		{
+			name:  "de otro usuario",
+			token: models.RefreshToken{UserID: "user-2", ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, st, jwtManager, user := refreshFixture()
+			token := tt.token
+			token.ID = "token-1"
+			token.FamilyID = "family-1"
+			if token.UserID == "" {
+				token.UserID = user.ID
+			}
+			signed := storeToken(t, st, jwtManager, user, token)
+
+			rotations := 0
+			if tt.rotateFails {
+				svc.refreshTokenRepo.(*MockRefreshTokenRepository).RotateFunc = func(ctx context.Context, oldID string, newToken *models.RefreshToken) (bool, error) {
+					rotations++
+					return false, nil
+				}
+			}
+
+			resp, err := svc.RefreshToken(context.Background(), signed)
+			if err != errors.ErrInvalidToken || resp != nil {
+				t.Fatalf("RefreshToken = %v, %v; se esperaba ErrInvalidToken", resp, err)
+			}
+			if len(st.tokens) != 1 {
+				t.Errorf("tokens registrados = %d, no se esperaba ninguno nuevo", len(st.tokens))
+			}
+			if got := len(st.familyRevokes) == 1; got != tt.wantFamilyRevoke {
+				t.Errorf("familias revocadas = %v, se esperaba revocación=%v", st.familyRevokes, tt.wantFamilyRevoke)
+			}
+			if tt.rotateFails && rotations != 1 {
+				t.Errorf("rotaciones = %d, se esperaba 1", rotations)
+			}
+		})
+	}
+}
+
+func TestAuthServiceLogout(t *testing.T) {
+	svc, st, jwtManager, user := refreshFixture()
+	signed := storeToken(t, st, jwtManager, user, models.RefreshToken{
+		ID: "token-1", UserID: user.ID, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour),
+	})
+
+	// Otro usuario no puede cerrar la sesión
+	if err := svc.Logout(context.Background(), "user-2", signed); err != errors.ErrInvalidToken {
+		t.Fatalf("Logout de otro usuario = %v, se esperaba ErrInvalidToken", err)
+	}
+	if len(st.familyRevokes) != 0 {
+		t.Fatalf("familias revocadas = %v, no se esperaba ninguna", st.familyRevokes)
+	}
+
+	if err := svc.Logout(context.Background(), user.ID, signed); err != nil {
+		t.Fatalf("Logout: error inesperado %v", err)
+	}
+	if len(st.familyRevokes) != 1 || st.familyRevokes[0] != "family-1" || st.active("family-1") != 0 {
+		t.Errorf("familias revocadas = %v, se esperaba family-1 sin tokens vigentes", st.familyRevokes)
+	}
+
+	// Tras el logout el refresh token ya no sirve
+	if _, err := svc.RefreshToken(context.Background(), signed); err != errors.ErrInvalidToken {
+		t.Errorf("RefreshToken tras Logout = %v, se esperaba ErrInvalidToken", err)
+	}
+
+	// Un access token no sirve para cerrar sesión
+	access, err := jwtManager.GenerateToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		t.Fatalf("error al generar access token: %v", err)
+	}
+	if err := svc.Logout(context.Background(), user.ID, access); err != errors.ErrInvalidToken {
+		t.Errorf("Logout con access token = %v, se esperaba ErrInvalidToken", err)
+	}
+}
+
+func TestAuthServiceLogoutAll(t *testing.T) {
+	svc, st, _, user := refreshFixture()
+
+	if err := svc.LogoutAll(context.Background(), user.ID); err != nil {
+		t.Fatalf("LogoutAll: error inesperado %v", err)
+	}
+	if len(st.userRevokes) != 1 || st.userRevokes[0] != user.ID {
+		t.Errorf("usuarios revocados = %v, se esperaba [%s]", st.userRevokes, user.ID)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// ChecklistService maneja la lógica de negocio de la checklist de una tarea
+type ChecklistService struct {
+	checklistRepo domain.ChecklistRepository
+	taskRepo      domain.TaskRepository
+	transactor    domain.Transactor
+	events        *EventHub
+}
+
+// NewChecklistService crea una nueva instancia de ChecklistService
+func NewChecklistService(checklistRepo domain.ChecklistRepository, taskRepo domain.TaskRepository, transactor domain.Transactor, events *EventHub) *ChecklistService {
+	return &ChecklistService{
+		checklistRepo: checklistRepo,
+		taskRepo:      taskRepo,
+		transactor:    transactor,
+		events:        events,
+	}
+}
+
+// ListItems obtiene la checklist de una tarea visible para el actor
+func (s *ChecklistService) ListItems(ctx context.Context, taskID string, actor *models.Actor) ([]models.ChecklistItem, error) {
+	if _, err := s.authorize(ctx, taskID, actor, TaskActionView); err != nil {
+		return nil, err
+	}
+
+	items, err := s.checklistRepo.List(ctx, taskID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener checklist: %v", err))
+	}
+
+	return items, nil
+}
+
+// CreateItem agrega un ítem a la checklist (requiere poder editar la tarea). Como
+// cambia el progreso de la tarea, se publica como una actualización de la tarea
+func (s *ChecklistService) CreateItem(ctx context.Context, taskID string, req *models.CreateChecklistItemRequest, actor *models.Actor) (*models.ChecklistItem, error) {
+	task, err := s.authorize(ctx, taskID, actor, TaskActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, errors.NewBadRequest("El texto del ítem no puede estar vacío")
+	}
+
+	var itemID string
+	_, err = applyTaskChange(ctx, s.transactor, s.taskRepo, s.events, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		var err error
+		itemID, err = s.checklistRepo.Create(ctx, actor.WorkspaceID, taskID, text, req.Position, actor.UserID)
+		return checklistWriteError(err, "error al crear ítem de checklist")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.checklistRepo.GetByID(ctx, taskID, itemID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener ítem de checklist: %v", err))
+	}
+
+	return item, nil
+}
+
+// UpdateItem edita, marca o mueve un ítem de la checklist (requiere poder editar la tarea)
+func (s *ChecklistService) UpdateItem(ctx context.Context, taskID, itemID string, req *models.UpdateChecklistItemRequest, actor *models.Actor) (*models.ChecklistItem, error) {
+	task, err := s.authorize(ctx, taskID, actor, TaskActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.checklistRepo.GetByID(ctx, taskID, itemID)
+	if err != nil {
+		return nil, errors.ErrChecklistItemNotFound
+	}
+
+	text := item.Text
+	if req.Text != nil {
+		text = strings.TrimSpace(*req.Text)
+		if text == "" {
+			return nil, errors.NewBadRequest("El texto del ítem no puede estar vacío")
+		}
+	}
+
+	done := item.Done
+	if req.Done != nil {
+		done = *req.Done
+	}
+
+	position := item.Position
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	_, err = applyTaskChange(ctx, s.transactor, s.taskRepo, s.events, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		err := s.checklistRepo.Update(ctx, actor.WorkspaceID, taskID, itemID, text, done, position, actor.UserID)
+		return checklistWriteError(err, "error al actualizar ítem de checklist")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.checklistRepo.GetByID(ctx, taskID, itemID)
+}
+
+// DeleteItem elimina un ítem de la checklist (requiere poder editar la tarea)
+func (s *ChecklistService) DeleteItem(ctx context.Context, taskID, itemID string, actor *models.Actor) error {
+	task, err := s.authorize(ctx, taskID, actor, TaskActionUpdate)
+	if err != nil {
+		return err
+	}
+
+	_, err = applyTaskChange(ctx, s.transactor, s.taskRepo, s.events, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		err := s.checklistRepo.Delete(ctx, actor.WorkspaceID, taskID, itemID, actor.UserID)
+		return checklistWriteError(err, "error al eliminar ítem de checklist")
+	})
+	return err
+}
+
+// authorize verifica que la tarea exista en el workspace y que el actor pueda realizar
+// la acción. Retorna la tarea para publicarla como estado previo del cambio
+func (s *ChecklistService) authorize(ctx context.Context, taskID string, actor *models.Actor, action TaskAction) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, action); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// checklistWriteError conserva los errores de negocio del repositorio y envuelve el resto
+func checklistWriteError(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := err.(*errors.AppError); ok {
+		return appErr
+	}
+	return errors.NewInternalServerError(fmt.Sprintf("%s: %v", message, err))
+}
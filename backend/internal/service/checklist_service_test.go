@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taskflow/backend/internal/models"
+)
+
+func TestChecklistWritesPublishTaskUpdates(t *testing.T) {
+	taskRepo := &MockTaskRepository{
+		GetByIDFunc: func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+			return policyTask(workspaceID), nil
+		},
+	}
+
+	var writes []string
+	checklistRepo := &MockChecklistRepository{
+		GetByIDFunc: func(ctx context.Context, taskID, id string) (*models.ChecklistItem, error) {
+			return &models.ChecklistItem{ID: id, TaskID: taskID, Text: "Revisar"}, nil
+		},
+		CreateFunc: func(ctx context.Context, workspaceID, taskID, text string, position *int, actorID string) (string, error) {
+			writes = append(writes, "create "+workspaceID+" "+actorID)
+			return "item-1", nil
+		},
+		UpdateFunc: func(ctx context.Context, workspaceID, taskID, id, text string, done bool, position int, actorID string) error {
+			writes = append(writes, "update "+workspaceID+" "+actorID)
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, workspaceID, taskID, id, actorID string) error {
+			writes = append(writes, "delete "+workspaceID+" "+actorID)
+			return nil
+		},
+	}
+
+	var published []*models.TaskChange
+	changeRepo := &MockTaskChangeRepository{
+		CreateFunc: func(ctx context.Context, change *models.TaskChange) error {
+			published = append(published, change)
+			return nil
+		},
+	}
+
+	svc := NewChecklistService(checklistRepo, taskRepo, &MockTransactor{}, NewEventHub(changeRepo, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour))
+	actor := policyActors["creator"]
+	ctx := context.Background()
+
+	if _, err := svc.CreateItem(ctx, "task-1", &models.CreateChecklistItemRequest{Text: "Revisar"}, actor); err != nil {
+		t.Fatalf("CreateItem: error inesperado %v", err)
+	}
+	done := true
+	if _, err := svc.UpdateItem(ctx, "task-1", "item-1", &models.UpdateChecklistItemRequest{Done: &done}, actor); err != nil {
+		t.Fatalf("UpdateItem: error inesperado %v", err)
+	}
+	if err := svc.DeleteItem(ctx, "task-1", "item-1", actor); err != nil {
+		t.Fatalf("DeleteItem: error inesperado %v", err)
+	}
+
+	wantWrites := []string{"create ws-1 creator", "update ws-1 creator", "delete ws-1 creator"}
+	if len(writes) != len(wantWrites) {
+		t.Fatalf("escrituras = %v, se esperaban %v", writes, wantWrites)
+	}
+	for i := range wantWrites {
+		if writes[i] != wantWrites[i] {
+			t.Errorf("escritura %d = %q, se esperaba %q", i, writes[i], wantWrites[i])
+		}
+	}
+
+	if len(published) != len(wantWrites) {
+		t.Fatalf("eventos publicados = %d, se esperaban %d", len(published), len(wantWrites))
+	}
+	for _, change := range published {
+		if change.Type != models.TaskChangeUpdated || change.TaskID != "task-1" || change.WorkspaceID != policyWorkspace {
+			t.Errorf("evento = %s %s en %s, se esperaba task.updated task-1 en ws-1", change.Type, change.TaskID, change.WorkspaceID)
+		}
+	}
+}
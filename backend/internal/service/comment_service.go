@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// mentionRegex detecta menciones con la forma @usuario@dominio.com
+var mentionRegex = regexp.MustCompile(`(?:^|[^a-zA-Z0-9._%+\-@])@([a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,})`)
+
+// CommentService maneja la lógica de negocio de comentarios de tareas
+type CommentService struct {
+	commentRepo   domain.CommentRepository
+	taskRepo      domain.TaskRepository
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewCommentService crea una nueva instancia de CommentService
+func NewCommentService(commentRepo domain.CommentRepository, taskRepo domain.TaskRepository, userRepo domain.UserRepository, workspaceRepo domain.WorkspaceRepository) *CommentService {
+	return &CommentService{
+		commentRepo:   commentRepo,
+		taskRepo:      taskRepo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// ListComments obtiene los comentarios raíz de una tarea paginados, con sus respuestas
+func (s *CommentService) ListComments(ctx context.Context, taskID string, page, pageSize int, actor *models.Actor) (*models.CommentsListResponse, error) {
+	if _, err := s.getVisibleTask(ctx, taskID, actor); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = normalizePagination(page, pageSize)
+
+	comments, total, err := s.commentRepo.ListByTask(ctx, taskID, page, pageSize)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener comentarios: %v", err))
+	}
+
+	return &models.CommentsListResponse{
+		Comments:   comments,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// CreateComment comenta una tarea visible para el actor. Las respuestas a una
+// respuesta se agrupan bajo el comentario raíz del hilo
+func (s *CommentService) CreateComment(ctx context.Context, taskID string, req *models.CreateCommentRequest, actor *models.Actor) (*models.Comment, error) {
+	if _, err := s.getVisibleTask(ctx, taskID, actor); err != nil {
+		return nil, err
+	}
+
+	var parentID *string
+	if req.ParentID != nil {
+		parent, err := s.commentRepo.GetByID(ctx, actor.WorkspaceID, *req.ParentID)
+		if err != nil || parent.TaskID != taskID {
+			return nil, errors.NewAppError(422, "El comentario padre no pertenece a esta tarea", "")
+		}
+
+		parentID = &parent.ID
+		if parent.ParentID != nil {
+			parentID = parent.ParentID
+		}
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.NewBadRequest("El comentario no puede estar vacío")
+	}
+
+	mentionIDs := s.resolveMentions(ctx, body, actor.WorkspaceID)
+
+	commentID, err := s.commentRepo.Create(ctx, taskID, parentID, actor.UserID, body, mentionIDs)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al crear comentario: %v", err))
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, actor.WorkspaceID, commentID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener comentario: %v", err))
+	}
+
+	return comment, nil
+}
+
+// UpdateComment edita un comentario propio conservando la versión anterior en el historial
+func (s *CommentService) UpdateComment(ctx context.Context, commentID string, req *models.UpdateCommentRequest, actor *models.Actor) (*models.Comment, error) {
+	comment, err := s.getVisibleComment(ctx, commentID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.AuthorID != actor.UserID {
+		return nil, errors.ErrForbidden
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.NewBadRequest("El comentario no puede estar vacío")
+	}
+
+	if body == comment.Body {
+		return comment, nil
+	}
+
+	mentionIDs := s.resolveMentions(ctx, body, actor.WorkspaceID)
+
+	if err := s.commentRepo.Update(ctx, commentID, body, actor.UserID, mentionIDs); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al actualizar comentario: %v", err))
+	}
+
+	comment, err = s.commentRepo.GetByID(ctx, actor.WorkspaceID, commentID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener comentario: %v", err))
+	}
+
+	return comment, nil
+}
+
+// DeleteComment elimina un comentario y sus respuestas (autor o administradores)
+func (s *CommentService) DeleteComment(ctx context.Context, commentID string, actor *models.Actor) error {
+	comment, err := s.getVisibleComment(ctx, commentID, actor)
+	if err != nil {
+		return err
+	}
+
+	if comment.AuthorID != actor.UserID && !actor.IsAdmin() && !actor.IsWorkspaceAdmin() {
+		return errors.ErrForbidden
+	}
+
+	if err := s.commentRepo.Delete(ctx, commentID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar comentario: %v", err))
+	}
+
+	return nil
+}
+
+// GetCommentHistory obtiene las versiones anteriores de un comentario
+func (s *CommentService) GetCommentHistory(ctx context.Context, commentID string, actor *models.Actor) ([]models.CommentEdit, error) {
+	if _, err := s.getVisibleComment(ctx, commentID, actor); err != nil {
+		return nil, err
+	}
+
+	edits, err := s.commentRepo.GetHistory(ctx, commentID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener historial del comentario: %v", err))
+	}
+
+	return edits, nil
+}
+
+// getVisibleTask obtiene la tarea y verifica que el actor pueda verla
+func (s *CommentService) getVisibleTask(ctx context.Context, taskID string, actor *models.Actor) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionView); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// getVisibleComment obtiene el comentario y verifica que el actor pueda ver su tarea
+func (s *CommentService) getVisibleComment(ctx context.Context, commentID string, actor *models.Actor) (*models.Comment, error) {
+	comment, err := s.commentRepo.GetByID(ctx, actor.WorkspaceID, commentID)
+	if err != nil {
+		return nil, errors.ErrCommentNotFound
+	}
+
+	if _, err := s.getVisibleTask(ctx, comment.TaskID, actor); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// resolveMentions obtiene los IDs de los miembros del workspace mencionados en el texto.
+// Las menciones a emails desconocidos o ajenos al workspace se ignoran
+func (s *CommentService) resolveMentions(ctx context.Context, body, workspaceID string) []string {
+	emails := ParseMentions(body)
+	mentionIDs := make([]string, 0, len(emails))
+
+	for _, email := range emails {
+		user, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			continue
+		}
+
+		if _, err := s.workspaceRepo.GetMember(ctx, workspaceID, user.ID); err != nil {
+			continue
+		}
+
+		mentionIDs = append(mentionIDs, user.ID)
+	}
+
+	return mentionIDs
+}
+
+// ParseMentions extrae los emails mencionados con @email, sin duplicados
+func ParseMentions(body string) []string {
+	matches := mentionRegex.FindAllStringSubmatch(body, -1)
+	seen := make(map[string]bool, len(matches))
+	emails := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		email := match[1]
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+
+	return emails
+}
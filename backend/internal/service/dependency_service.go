@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// DependencyService maneja la lógica de negocio de las dependencias entre tareas
+type DependencyService struct {
+	dependencyRepo domain.DependencyRepository
+	taskRepo       domain.TaskRepository
+	transactor     domain.Transactor
+}
+
+// NewDependencyService crea una nueva instancia de DependencyService
+func NewDependencyService(dependencyRepo domain.DependencyRepository, taskRepo domain.TaskRepository, transactor domain.Transactor) *DependencyService {
+	return &DependencyService{
+		dependencyRepo: dependencyRepo,
+		taskRepo:       taskRepo,
+		transactor:     transactor,
+	}
+}
+
+// AddDependency registra una dependencia entre la tarea y otra del workspace.
+// Rechaza las dependencias que crearían un ciclo en el grafo
+func (s *DependencyService) AddDependency(ctx context.Context, taskID string, req *models.AddDependencyRequest, actor *models.Actor) (*models.TaskDependencyGraph, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionUpdate); err != nil {
+		return nil, err
+	}
+
+	if req.TaskID == taskID {
+		return nil, errors.NewAppError(422, "Una tarea no puede depender de sí misma", "")
+	}
+
+	other, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, req.TaskID, actor.UserID)
+	if err != nil || !CanPerformTaskAction(other, actor, TaskActionView) {
+		return nil, errors.NewAppError(422, "La tarea relacionada no existe en este workspace", "")
+	}
+
+	blockerID, blockedID := other.ID, task.ID
+	if req.Type == models.DependencyBlocks {
+		blockerID, blockedID = task.ID, other.ID
+	}
+
+	// El grafo se lee y se amplía con el workspace bloqueado: dos aristas que juntas
+	// cierran un ciclo no pueden verificarse a la vez contra el grafo sin ninguna
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.dependencyRepo.LockGraph(ctx, actor.WorkspaceID); err != nil {
+			return errors.NewInternalServerError(fmt.Sprintf("error al bloquear dependencias: %v", err))
+		}
+
+		edges, err := s.dependencyRepo.ListEdges(ctx, actor.WorkspaceID)
+		if err != nil {
+			return errors.NewInternalServerError(fmt.Sprintf("error al obtener dependencias: %v", err))
+		}
+
+		// La nueva arista blocker → blocked cierra un ciclo si blocked ya bloquea,
+		// directa o indirectamente, a blocker
+		if path := dependencyPath(edges, blockedID, blockerID); path != nil {
+			return errors.NewAppError(
+				422,
+				"La dependencia crearía un ciclo",
+				"ciclo: "+strings.Join(append(path, blockedID), " → "),
+			)
+		}
+
+		if err := s.dependencyRepo.Add(ctx, actor.WorkspaceID, blockerID, blockedID, actor.UserID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al crear dependencia: %v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDependencies(ctx, taskID, actor)
+}
+
+// RemoveDependency elimina la dependencia entre la tarea y otra, en cualquier dirección
+func (s *DependencyService) RemoveDependency(ctx context.Context, taskID, otherID string, actor *models.Actor) error {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionUpdate); err != nil {
+		return err
+	}
+
+	err = s.dependencyRepo.Remove(ctx, actor.WorkspaceID, otherID, taskID, actor.UserID)
+	if err == errors.ErrDependencyNotFound {
+		err = s.dependencyRepo.Remove(ctx, actor.WorkspaceID, taskID, otherID, actor.UserID)
+	}
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar dependencia: %v", err))
+	}
+
+	return nil
+}
+
+// GetDependencies obtiene el grafo de dependencias de la tarea: las tareas por las
+// que espera (upstream) y las que esperan por ella (downstream), directas e
+// indirectas. Solo se incluyen las tareas visibles para el actor
+func (s *DependencyService) GetDependencies(ctx context.Context, taskID string, actor *models.Actor) (*models.TaskDependencyGraph, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionView); err != nil {
+		return nil, err
+	}
+
+	edges, err := s.dependencyRepo.ListEdges(ctx, actor.WorkspaceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener dependencias: %v", err))
+	}
+
+	blockers, err := s.dependencyRepo.CountOpenBlockers(ctx, actor.WorkspaceID, taskID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al verificar dependencias: %v", err))
+	}
+
+	blocks := make(map[string][]string)
+	blockedBy := make(map[string][]string)
+	for _, edge := range edges {
+		blocks[edge.BlockerID] = append(blocks[edge.BlockerID], edge.BlockedID)
+		blockedBy[edge.BlockedID] = append(blockedBy[edge.BlockedID], edge.BlockerID)
+	}
+
+	upstream := dependencyDepths(blockedBy, taskID)
+	downstream := dependencyDepths(blocks, taskID)
+
+	ids := make([]string, 0, len(upstream)+len(downstream))
+	for id := range upstream {
+		ids = append(ids, id)
+	}
+	for id := range downstream {
+		ids = append(ids, id)
+	}
+
+	graph := &models.TaskDependencyGraph{
+		TaskID:     taskID,
+		Blocked:    blockers > 0,
+		Upstream:   []models.TaskDependencyNode{},
+		Downstream: []models.TaskDependencyNode{},
+		Edges:      []models.TaskDependencyEdge{},
+	}
+
+	if len(ids) == 0 {
+		return graph, nil
+	}
+
+	tasks, _, err := s.taskRepo.GetAll(ctx, actor.WorkspaceID, models.TaskFilter{IDs: ids}, models.TaskSort{}, 1, len(ids))
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener tareas dependientes: %v", err))
+	}
+
+	included := map[string]bool{taskID: true}
+	for i := range tasks {
+		related := &tasks[i]
+		if !CanPerformTaskAction(related, actor, TaskActionView) {
+			continue
+		}
+		included[related.ID] = true
+
+		if depth, ok := upstream[related.ID]; ok {
+			graph.Upstream = append(graph.Upstream, dependencyNode(related, depth))
+		}
+		if depth, ok := downstream[related.ID]; ok {
+			graph.Downstream = append(graph.Downstream, dependencyNode(related, depth))
+		}
+	}
+
+	sortDependencyNodes(graph.Upstream)
+	sortDependencyNodes(graph.Downstream)
+
+	for _, edge := range edges {
+		if included[edge.BlockerID] && included[edge.BlockedID] {
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	return graph, nil
+}
+
+// dependencyPath busca un camino de dependencias de from a to (from bloquea, directa
+// o indirectamente, a to). Retorna los IDs del camino o nil si no existe
+func dependencyPath(edges []models.TaskDependencyEdge, from, to string) []string {
+	blocks := make(map[string][]string)
+	for _, edge := range edges {
+		blocks[edge.BlockerID] = append(blocks[edge.BlockerID], edge.BlockedID)
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == to {
+			path := []string{}
+			for id := to; id != ""; id = previous[id] {
+				path = append([]string{id}, path...)
+			}
+			return path
+		}
+
+		for _, next := range blocks[current] {
+			if _, seen := previous[next]; !seen {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dependencyDepths recorre el grafo desde start y retorna la distancia mínima a
+// cada tarea alcanzable (1 = dependencia directa)
+func dependencyDepths(adjacency map[string][]string, start string) map[string]int {
+	depths := make(map[string]int)
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range adjacency[current] {
+			if _, seen := depths[next]; seen || next == start {
+				continue
+			}
+			depths[next] = depths[current] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	return depths
+}
+
+// dependencyNode resume una tarea para el grafo de dependencias
+func dependencyNode(task *models.Task, depth int) models.TaskDependencyNode {
+	return models.TaskDependencyNode{
+		ID:         task.ID,
+		Title:      task.Title,
+		Status:     task.Status,
+		Priority:   task.Priority,
+		AssignedTo: task.AssignedTo,
+		DueDate:    task.DueDate,
+		Depth:      depth,
+	}
+}
+
+// sortDependencyNodes ordena los nodos por distancia y luego por título
+func sortDependencyNodes(nodes []models.TaskDependencyNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Depth != nodes[j].Depth {
+			return nodes[i].Depth < nodes[j].Depth
+		}
+		return nodes[i].Title < nodes[j].Title
+	})
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/taskflow/backend/internal/models"
+)
+
+func TestDependencyPath(t *testing.T) {
+	// a → b → c, a → d y e aislada
+	edges := []models.TaskDependencyEdge{
+		{BlockerID: "a", BlockedID: "b"},
+		{BlockerID: "b", BlockedID: "c"},
+		{BlockerID: "a", BlockedID: "d"},
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+	}{
+		{name: "directa", from: "a", to: "b", want: []string{"a", "b"}},
+		{name: "transitiva", from: "a", to: "c", want: []string{"a", "b", "c"}},
+		{name: "sentido contrario", from: "c", to: "a", want: nil},
+		{name: "ramas separadas", from: "d", to: "c", want: nil},
+		{name: "tarea sin dependencias", from: "e", to: "a", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependencyPath(edges, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependencyPath(%s, %s) = %v, se esperaba %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+const (
+	// subscriptionBufferSize eventos pendientes que admite una suscripción antes de descartarla
+	subscriptionBufferSize = 64
+	// maxEventBacklog máximo de eventos que se recuperan al retomar con Last-Event-ID
+	maxEventBacklog = 500
+	// eventResumeWindow cuánto antes que el último evento recibido se vuelve a buscar al
+	// retomar. Los IDs se asignan al registrar el evento pero se confirman con la
+	// transacción del cambio, así que uno menor puede confirmarse después; la ventana
+	// cubre ese intervalo y los repetidos se descartan por ID
+	eventResumeWindow = time.Minute
+)
+
+// EventHub publica los eventos en tiempo real de las tareas y los reparte entre las
+// suscripciones de esta instancia. Los eventos se guardan en TaskChangeRepository,
+// que avisa a todas las instancias; cada una los recibe con Run
+type EventHub struct {
+	changeRepo    domain.TaskChangeRepository
+	workspaceRepo domain.WorkspaceRepository
+	userRepo      domain.UserRepository
+	retention     time.Duration
+
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+	lastID        int64               // mayor ID repartido; desde ahí se recupera si se pierden avisos
+	lastCreatedAt time.Time           // fecha del evento lastID
+	dispatched    map[int64]time.Time // IDs repartidos dentro de la ventana de lastID, con su fecha
+	prunedAt      time.Time
+}
+
+// Subscription recibe los eventos del workspace activo del actor que este puede ver
+type Subscription struct {
+	actor  models.Actor
+	events chan models.TaskChange
+}
+
+// Events canal de eventos de la suscripción. Se cierra si el cliente no los consume a
+// tiempo; en ese caso debe reconectar con Last-Event-ID
+func (s *Subscription) Events() <-chan models.TaskChange {
+	return s.events
+}
+
+// NewEventHub crea una nueva instancia de EventHub
+func NewEventHub(changeRepo domain.TaskChangeRepository, workspaceRepo domain.WorkspaceRepository, userRepo domain.UserRepository, retention time.Duration) *EventHub {
+	return &EventHub{
+		changeRepo:    changeRepo,
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+		retention:     retention,
+		subscriptions: make(map[*Subscription]struct{}),
+		dispatched:    make(map[int64]time.Time),
+	}
+}
+
+// Publish registra un evento sobre la tarea. before es la tarea antes del cambio (nil
+// si no existía) y permite avisar a quienes dejan de verla. Debe llamarse dentro de
+// la transacción del cambio: si falla, el cambio se revierte y el evento nunca queda
+// confirmado sin él. El evento llega a todo el workspace, así que la tarea se publica
+// sin las etiquetas personales del actor
+func (h *EventHub) Publish(ctx context.Context, changeType string, task, before *models.Task, actor *models.Actor) error {
+	change := &models.TaskChange{
+		Type:        changeType,
+		WorkspaceID: task.WorkspaceID,
+		TaskID:      task.ID,
+		ActorID:     actor.UserID,
+		Task:        withoutPersonalLabels(task),
+	}
+	if before != nil {
+		change.PreviousViewers = taskViewers(before)
+	}
+
+	if err := h.changeRepo.Create(ctx, change); err != nil {
+		return fmt.Errorf("error al publicar evento %s de la tarea %s: %w", changeType, task.ID, err)
+	}
+	return nil
+}
+
+// withoutPersonalLabels copia la tarea quitando las etiquetas personales
+func withoutPersonalLabels(task *models.Task) *models.Task {
+	shared := *task
+	shared.Labels = []models.Label{}
+	for _, label := range task.Labels {
+		if label.UserID == nil {
+			shared.Labels = append(shared.Labels, label)
+		}
+	}
+	return &shared
+}
+
+// Subscribe registra una suscripción a los eventos del workspace activo del actor. Con
+// lastEventID distinto de 0 retorna además los eventos visibles posteriores a ese ID,
+// que el cliente debe recibir antes que los del canal. Por la ventana de
+// eventResumeWindow pueden incluir eventos que el cliente ya recibió: la entrega es
+// al menos una vez y el cliente descarta los IDs repetidos. El canal también puede
+// repetir eventos de los pendientes
+func (h *EventHub) Subscribe(ctx context.Context, actor *models.Actor, lastEventID int64) (*Subscription, []models.TaskChange, error) {
+	sub := &Subscription{
+		actor:  *actor,
+		events: make(chan models.TaskChange, subscriptionBufferSize),
+	}
+
+	// Se registra antes de leer los pendientes para no perder los que lleguen mientras tanto
+	h.mu.Lock()
+	h.subscriptions[sub] = struct{}{}
+	h.mu.Unlock()
+
+	if lastEventID == 0 {
+		return sub, nil, nil
+	}
+
+	changes, err := h.changeRepo.ListAfter(ctx, actor.WorkspaceID, lastEventID, eventResumeWindow, maxEventBacklog+1)
+	if err != nil {
+		h.Unsubscribe(sub)
+		return nil, nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener eventos: %v", err))
+	}
+
+	if len(changes) > maxEventBacklog {
+		h.Unsubscribe(sub)
+		return nil, nil, errors.NewAppError(410, "Hay demasiados eventos desde Last-Event-ID", "recargue las tareas y vuelva a conectarse sin Last-Event-ID")
+	}
+
+	backlog := []models.TaskChange{}
+	for i := range changes {
+		if change, ok := TaskChangeFor(&changes[i], actor); ok {
+			backlog = append(backlog, change)
+		}
+	}
+
+	return sub, backlog, nil
+}
+
+// Unsubscribe da de baja la suscripción y cierra su canal
+func (h *EventHub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscriptions[sub]; ok {
+		delete(h.subscriptions, sub)
+		close(sub.events)
+	}
+}
+
+// Revalidate vuelve a leer el rol del usuario y su membresía en el workspace de la
+// suscripción, que se fijaron al conectar. Si ya no es miembro, o no se puede
+// verificar, la da de baja y retorna false: el cliente reconecta con Last-Event-ID y
+// la autorización se resuelve de nuevo. Los streams la llaman en cada heartbeat
+func (h *EventHub) Revalidate(ctx context.Context, sub *Subscription) bool {
+	h.mu.Lock()
+	actor := sub.actor
+	h.mu.Unlock()
+
+	user, err := h.userRepo.GetByID(ctx, actor.UserID)
+	if err != nil {
+		log.Printf("⚠️ Suscripción de %s descartada: no se pudo verificar el usuario: %v\n", actor.UserID, err)
+		h.Unsubscribe(sub)
+		return false
+	}
+
+	member, err := h.workspaceRepo.GetMember(ctx, actor.WorkspaceID, actor.UserID)
+	if err != nil {
+		if err != errors.ErrMemberNotFound {
+			log.Printf("⚠️ Suscripción de %s descartada: no se pudo verificar la membresía: %v\n", actor.UserID, err)
+		}
+		h.Unsubscribe(sub)
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscriptions[sub]; !ok {
+		return false
+	}
+	sub.actor.Role = user.Role
+	sub.actor.WorkspaceRole = member.Role
+	return true
+}
+
+// Close da de baja todas las suscripciones al apagar la instancia. Los clientes
+// reconectan con Last-Event-ID y retoman desde otra instancia
+func (h *EventHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscriptions {
+		delete(h.subscriptions, sub)
+		close(sub.events)
+	}
+}
+
+// Run recibe los avisos de eventos de todas las instancias y los reparte hasta que
+// se cancele el contexto
+func (h *EventHub) Run(ctx context.Context, listener domain.TaskChangeListener) {
+	err := listener.Listen(ctx, func(id int64) {
+		if id == 0 {
+			h.catchUp(ctx)
+			return
+		}
+
+		change, err := h.changeRepo.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("🔴 ERROR al obtener evento %d: %v\n", id, err)
+			return
+		}
+		h.dispatch(change)
+	})
+	if err != nil {
+		log.Printf("🔴 ERROR en recepción de eventos de tareas: %v\n", err)
+	}
+}
+
+// Purge elimina los eventos anteriores a now - retención. Retorna cuántos eliminó
+func (h *EventHub) Purge(ctx context.Context, now time.Time) (int, error) {
+	return h.changeRepo.DeleteBefore(ctx, now.Add(-h.retention))
+}
+
+// catchUp reparte los eventos posteriores al último recibido, tras perder avisos. Los
+// ya repartidos se descartan en dispatch
+func (h *EventHub) catchUp(ctx context.Context) {
+	h.mu.Lock()
+	lastID := h.lastID
+	h.mu.Unlock()
+
+	// Sin eventos previos no hay desde dónde recuperar
+	if lastID == 0 {
+		return
+	}
+
+	// La primera página incluye los confirmados tarde dentro de la ventana; las
+	// siguientes solo avanzan por ID
+	window := eventResumeWindow
+	for {
+		changes, err := h.changeRepo.ListAfter(ctx, "", lastID, window, maxEventBacklog)
+		if err != nil {
+			log.Printf("🔴 ERROR al recuperar eventos posteriores a %d: %v\n", lastID, err)
+			return
+		}
+
+		advanced := false
+		for i := range changes {
+			h.dispatch(&changes[i])
+			if changes[i].ID > lastID {
+				lastID = changes[i].ID
+				advanced = true
+			}
+		}
+
+		if len(changes) < maxEventBacklog || !advanced {
+			return
+		}
+		window = 0
+	}
+}
+
+// dispatch envía el evento a las suscripciones que pueden verlo, salvo que ya se haya
+// repartido. Las que tienen el buffer lleno se descartan para no frenar al resto
+func (h *EventHub) dispatch(change *models.TaskChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.dispatched[change.ID]; ok {
+		return
+	}
+	h.dispatched[change.ID] = change.CreatedAt
+
+	if change.ID > h.lastID {
+		h.lastID = change.ID
+		h.lastCreatedAt = change.CreatedAt
+		h.pruneDispatched()
+	}
+
+	for sub := range h.subscriptions {
+		visible, ok := TaskChangeFor(change, &sub.actor)
+		if !ok {
+			continue
+		}
+
+		select {
+		case sub.events <- visible:
+		default:
+			log.Printf("⚠️ Suscripción de %s descartada: no consume eventos a tiempo\n", sub.actor.UserID)
+			delete(h.subscriptions, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// pruneDispatched olvida los IDs repartidos que ya no entran en la ventana de catchUp.
+// Recorre el mapa como mucho una vez por ventana. Debe llamarse con mu tomado
+func (h *EventHub) pruneDispatched() {
+	if h.lastCreatedAt.Sub(h.prunedAt) < eventResumeWindow {
+		return
+	}
+
+	cutoff := h.lastCreatedAt.Add(-eventResumeWindow)
+	for id, createdAt := range h.dispatched {
+		if createdAt.Before(cutoff) {
+			delete(h.dispatched, id)
+		}
+	}
+	h.prunedAt = h.lastCreatedAt
+}
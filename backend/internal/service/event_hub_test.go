@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// changeStore eventos confirmados en memoria. ListAfter imita la consulta de
+// PostgreSQL: ID mayor o creado hasta window antes que afterID
+type changeStore struct {
+	changes []models.TaskChange
+}
+
+func (st *changeStore) repo() *MockTaskChangeRepository {
+	return &MockTaskChangeRepository{
+		ListAfterFunc: func(ctx context.Context, workspaceID string, afterID int64, window time.Duration, limit int) ([]models.TaskChange, error) {
+			var ref *time.Time
+			for i := range st.changes {
+				if st.changes[i].ID == afterID {
+					ref = &st.changes[i].CreatedAt
+				}
+			}
+
+			result := []models.TaskChange{}
+			for _, change := range st.changes {
+				if change.ID == afterID || (workspaceID != "" && change.WorkspaceID != workspaceID) {
+					continue
+				}
+				if change.ID > afterID || (ref != nil && !change.CreatedAt.Before(ref.Add(-window))) {
+					result = append(result, change)
+				}
+			}
+			sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+			if len(result) > limit {
+				result = result[:limit]
+			}
+			return result, nil
+		},
+	}
+}
+
+// commit agrega un evento confirmado de la tarea de policyTask
+func (st *changeStore) commit(id int64, workspaceID string, createdAt time.Time) models.TaskChange {
+	change := models.TaskChange{
+		ID:          id,
+		Type:        models.TaskChangeUpdated,
+		WorkspaceID: workspaceID,
+		TaskID:      "task-1",
+		Task:        policyTask(workspaceID),
+		CreatedAt:   createdAt,
+	}
+	st.changes = append(st.changes, change)
+	return change
+}
+
+// changeIDs IDs de los eventos en orden
+func changeIDs(changes []models.TaskChange) []int64 {
+	ids := []int64{}
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+	return ids
+}
+
+// drain IDs de los eventos pendientes en el canal de la suscripción
+func drain(sub *Subscription) []int64 {
+	ids := []int64{}
+	for {
+		select {
+		case change := <-sub.Events():
+			ids = append(ids, change.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestSubscribeResumesLateCommittedEvents(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	st := &changeStore{}
+	st.commit(1, policyWorkspace, now.Add(-2*eventResumeWindow))
+	// 3 se registró antes que 4 pero se confirmó después de que el cliente recibiera 4
+	st.commit(3, policyWorkspace, now.Add(-time.Second))
+	st.commit(4, policyWorkspace, now)
+	st.commit(5, otherPolicyWorkspace, now.Add(time.Second))
+	st.commit(6, policyWorkspace, now.Add(2*time.Second))
+
+	hub := NewEventHub(st.repo(), &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour)
+	sub, backlog, err := hub.Subscribe(context.Background(), policyActors["creator"], 4)
+	if err != nil {
+		t.Fatalf("Subscribe: error inesperado %v", err)
+	}
+	defer hub.Unsubscribe(sub)
+
+	// 1 queda fuera de la ventana, 4 ya se recibió y 5 es de otro workspace
+	if got := changeIDs(backlog); !reflect.DeepEqual(got, []int64{3, 6}) {
+		t.Errorf("pendientes = %v, se esperaban [3 6]", got)
+	}
+}
+
+func TestSubscribeBacklogFiltersByVisibility(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	st := &changeStore{}
+	st.commit(1, policyWorkspace, now)
+	st.commit(2, policyWorkspace, now)
+	// El evento 3 saca a stranger de la tarea: lo recibe para dejar de verla
+	st.commit(3, policyWorkspace, now)
+	st.changes[len(st.changes)-1].PreviousViewers = []string{"stranger"}
+	st.commit(4, policyWorkspace, now)
+
+	hub := NewEventHub(st.repo(), &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour)
+	tests := []struct {
+		actor string
+		want  []int64
+	}{
+		{actor: "creator", want: []int64{2, 3, 4}},
+		{actor: "global admin", want: []int64{2, 3, 4}},
+		{actor: "non-member", want: []int64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.actor, func(t *testing.T) {
+			sub, backlog, err := hub.Subscribe(context.Background(), policyActors[tt.actor], 1)
+			if err != nil {
+				t.Fatalf("Subscribe: error inesperado %v", err)
+			}
+			defer hub.Unsubscribe(sub)
+
+			if got := changeIDs(backlog); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pendientes = %v, se esperaban %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokedChangeIsRedacted(t *testing.T) {
+	st := &changeStore{}
+	change := st.commit(3, policyWorkspace, time.Now())
+	change.PreviousViewers = []string{"stranger"}
+
+	revoked, ok := TaskChangeFor(&change, policyActors["non-member"])
+	if !ok {
+		t.Fatal("quien dejó de ver la tarea no recibió el evento")
+	}
+	if revoked.Type != models.TaskChangeRevoked || revoked.Task != nil {
+		t.Errorf("evento = %s con tarea %v, se esperaba task.revoked sin tarea", revoked.Type, revoked.Task)
+	}
+
+	data, err := json.Marshal(revoked)
+	if err != nil {
+		t.Fatalf("json.Marshal: error inesperado %v", err)
+	}
+	if want := `{"id":3,"type":"task.revoked","task_id":"task-1"}`; string(data) != want {
+		t.Errorf("JSON = %s, se esperaba %s", data, want)
+	}
+
+	// Quien todavía ve la tarea recibe el evento completo
+	full, ok := TaskChangeFor(&change, policyActors["creator"])
+	if !ok || full.Type != models.TaskChangeUpdated || full.Task == nil {
+		t.Errorf("evento del creador = %+v, se esperaba task.updated con la tarea", full)
+	}
+}
+
+func TestDispatchFiltersByWorkspaceAndVisibility(t *testing.T) {
+	hub := NewEventHub(&MockTaskChangeRepository{}, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour)
+
+	subs := map[string]*Subscription{}
+	for name, actor := range map[string]*models.Actor{
+		"creator":    policyActors["creator"],
+		"non-member": policyActors["non-member"],
+		"other workspace": {
+			UserID: "creator", Role: models.RoleMember, WorkspaceID: otherPolicyWorkspace, WorkspaceRole: models.WorkspaceRoleMember,
+		},
+	} {
+		sub, _, err := hub.Subscribe(context.Background(), actor, 0)
+		if err != nil {
+			t.Fatalf("Subscribe: error inesperado %v", err)
+		}
+		defer hub.Unsubscribe(sub)
+		subs[name] = sub
+	}
+
+	st := &changeStore{}
+	change := st.commit(1, policyWorkspace, time.Now())
+	hub.dispatch(&change)
+
+	want := map[string][]int64{"creator": {1}, "non-member": {}, "other workspace": {}}
+	for name, sub := range subs {
+		if got := drain(sub); !reflect.DeepEqual(got, want[name]) {
+			t.Errorf("%s recibió %v, se esperaba %v", name, got, want[name])
+		}
+	}
+}
+
+func TestCatchUpDeliversLateEventsOnce(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	st := &changeStore{}
+	hub := NewEventHub(st.repo(), &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour)
+
+	sub, _, err := hub.Subscribe(context.Background(), policyActors["creator"], 0)
+	if err != nil {
+		t.Fatalf("Subscribe: error inesperado %v", err)
+	}
+	defer hub.Unsubscribe(sub)
+
+	// Llegan los avisos de 1 y 3; 2 todavía no se confirmó
+	for _, id := range []int64{1, 3} {
+		change := st.commit(id, policyWorkspace, now.Add(time.Duration(id)*time.Second))
+		hub.dispatch(&change)
+	}
+	if got := drain(sub); !reflect.DeepEqual(got, []int64{1, 3}) {
+		t.Fatalf("recibidos = %v, se esperaban [1 3]", got)
+	}
+
+	// Se pierden los avisos de 2 (confirmado tarde) y 4
+	st.commit(2, policyWorkspace, now.Add(2*time.Second))
+	st.commit(4, policyWorkspace, now.Add(4*time.Second))
+	hub.catchUp(context.Background())
+
+	if got := drain(sub); !reflect.DeepEqual(got, []int64{2, 4}) {
+		t.Errorf("recuperados = %v, se esperaban [2 4] sin repetir 1 ni 3", got)
+	}
+
+	// Un aviso que llega después de recuperarlo no se reparte de nuevo
+	late := st.changes[len(st.changes)-1]
+	hub.dispatch(&late)
+	if got := drain(sub); len(got) != 0 {
+		t.Errorf("repetidos = %v, no se esperaba ninguno", got)
+	}
+}
+
+func TestCloseEndsSubscriptions(t *testing.T) {
+	hub := NewEventHub(&MockTaskChangeRepository{}, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour)
+	sub, _, err := hub.Subscribe(context.Background(), policyActors["creator"], 0)
+	if err != nil {
+		t.Fatalf("Subscribe: error inesperado %v", err)
+	}
+
+	hub.Close()
+	if _, open := <-sub.Events(); open {
+		t.Errorf("el canal sigue abierto tras Close")
+	}
+
+	// Los streams se dan de baja al terminar; no debe fallar tras Close
+	hub.Unsubscribe(sub)
+}
+
+func TestRevalidateAppliesMembershipChanges(t *testing.T) {
+	role := models.WorkspaceRoleAdmin
+	var memberErr error
+	workspaceRepo := &MockWorkspaceRepository{
+		GetMemberFunc: func(ctx context.Context, workspaceID, userID string) (*models.WorkspaceMember, error) {
+			if memberErr != nil {
+				return nil, memberErr
+			}
+			return &models.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: role}, nil
+		},
+	}
+	userRepo := &MockUserRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.User, error) {
+			return &models.User{ID: id, Role: models.RoleMember}, nil
+		},
+	}
+
+	hub := NewEventHub(&MockTaskChangeRepository{}, workspaceRepo, userRepo, time.Hour)
+	sub, _, err := hub.Subscribe(context.Background(), policyActors["non-member"], 0)
+	if err != nil {
+		t.Fatalf("Subscribe: error inesperado %v", err)
+	}
+	defer hub.Unsubscribe(sub)
+
+	// Ascendido a administrador del workspace: desde ahora ve todas las tareas
+	if !hub.Revalidate(context.Background(), sub) {
+		t.Fatal("Revalidate cerró la suscripción de un miembro")
+	}
+	change := (&changeStore{}).commit(1, policyWorkspace, time.Now())
+	hub.dispatch(&change)
+	if got := drain(sub); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("recibidos = %v, se esperaba [1] con el nuevo rol", got)
+	}
+
+	// Eliminado del workspace: la suscripción se cierra
+	memberErr = errors.ErrMemberNotFound
+	if hub.Revalidate(context.Background(), sub) {
+		t.Error("Revalidate mantuvo la suscripción de quien ya no es miembro")
+	}
+	if _, open := <-sub.Events(); open {
+		t.Error("el canal sigue abierto tras dejar de ser miembro")
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// defaultLabelColor color de las etiquetas creadas sin color
+const defaultLabelColor = "#808080"
+
+// LabelService maneja la lógica de negocio de las etiquetas de tareas
+type LabelService struct {
+	labelRepo domain.LabelRepository
+	taskRepo  domain.TaskRepository
+}
+
+// NewLabelService crea una nueva instancia de LabelService
+func NewLabelService(labelRepo domain.LabelRepository, taskRepo domain.TaskRepository) *LabelService {
+	return &LabelService{
+		labelRepo: labelRepo,
+		taskRepo:  taskRepo,
+	}
+}
+
+// ListLabels obtiene las etiquetas del workspace y las personales del actor
+func (s *LabelService) ListLabels(ctx context.Context, actor *models.Actor) ([]models.Label, error) {
+	labels, err := s.labelRepo.List(ctx, actor.WorkspaceID, actor.UserID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener etiquetas: %v", err))
+	}
+
+	return labels, nil
+}
+
+// CreateLabel crea una etiqueta del workspace o, con personal, solo para el actor
+func (s *LabelService) CreateLabel(ctx context.Context, req *models.CreateLabelRequest, actor *models.Actor) (*models.Label, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewBadRequest("El nombre de la etiqueta no puede estar vacío")
+	}
+
+	color := req.Color
+	if color == "" {
+		color = defaultLabelColor
+	}
+
+	var userID *string
+	if req.Personal {
+		userID = &actor.UserID
+	}
+
+	labelID, err := s.labelRepo.Create(ctx, actor.WorkspaceID, userID, name, color, actor.UserID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al crear etiqueta: %v", err))
+	}
+
+	return s.labelRepo.GetByID(ctx, actor.WorkspaceID, labelID)
+}
+
+// UpdateLabel cambia nombre o color de una etiqueta que el actor puede administrar
+func (s *LabelService) UpdateLabel(ctx context.Context, labelID string, req *models.UpdateLabelRequest, actor *models.Actor) (*models.Label, error) {
+	label, err := s.getManageableLabel(ctx, labelID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	name := label.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewBadRequest("El nombre de la etiqueta no puede estar vacío")
+		}
+	}
+
+	color := label.Color
+	if req.Color != nil {
+		color = *req.Color
+	}
+
+	if err := s.labelRepo.Update(ctx, actor.WorkspaceID, labelID, name, color); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al actualizar etiqueta: %v", err))
+	}
+
+	return s.labelRepo.GetByID(ctx, actor.WorkspaceID, labelID)
+}
+
+// DeleteLabel elimina una etiqueta que el actor puede administrar y la quita de sus tareas
+func (s *LabelService) DeleteLabel(ctx context.Context, labelID string, actor *models.Actor) error {
+	if _, err := s.getManageableLabel(ctx, labelID, actor); err != nil {
+		return err
+	}
+
+	if err := s.labelRepo.Delete(ctx, actor.WorkspaceID, labelID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar etiqueta: %v", err))
+	}
+
+	return nil
+}
+
+// AttachLabel aplica una etiqueta visible para el actor a una tarea que puede editar
+func (s *LabelService) AttachLabel(ctx context.Context, taskID string, req *models.AttachLabelRequest, actor *models.Actor) (*models.Task, error) {
+	label, err := s.prepareTaskLabel(ctx, taskID, req.LabelID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.labelRepo.Attach(ctx, actor.WorkspaceID, taskID, label, actor.UserID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al aplicar etiqueta: %v", err))
+	}
+
+	return s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+}
+
+// DetachLabel quita una etiqueta de una tarea que el actor puede editar
+func (s *LabelService) DetachLabel(ctx context.Context, taskID, labelID string, actor *models.Actor) (*models.Task, error) {
+	label, err := s.prepareTaskLabel(ctx, taskID, labelID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.labelRepo.Detach(ctx, actor.WorkspaceID, taskID, label, actor.UserID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al quitar etiqueta: %v", err))
+	}
+
+	return s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+}
+
+// prepareTaskLabel verifica que el actor pueda editar la tarea y ver la etiqueta
+func (s *LabelService) prepareTaskLabel(ctx context.Context, taskID, labelID string, actor *models.Actor) (*models.Label, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionUpdate); err != nil {
+		return nil, err
+	}
+
+	return s.getVisibleLabel(ctx, labelID, actor)
+}
+
+// getVisibleLabel obtiene una etiqueta del workspace o personal del actor. Las
+// etiquetas personales de otros usuarios se tratan como inexistentes
+func (s *LabelService) getVisibleLabel(ctx context.Context, labelID string, actor *models.Actor) (*models.Label, error) {
+	label, err := s.labelRepo.GetByID(ctx, actor.WorkspaceID, labelID)
+	if err != nil {
+		return nil, errors.ErrLabelNotFound
+	}
+
+	if label.UserID != nil && *label.UserID != actor.UserID {
+		return nil, errors.ErrLabelNotFound
+	}
+
+	return label, nil
+}
+
+// getManageableLabel obtiene una etiqueta que el actor puede editar o eliminar: las
+// personales propias y, del workspace, las que creó o todas si es administrador
+func (s *LabelService) getManageableLabel(ctx context.Context, labelID string, actor *models.Actor) (*models.Label, error) {
+	label, err := s.getVisibleLabel(ctx, labelID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if label.UserID != nil || actor.IsAdmin() || actor.IsWorkspaceAdmin() {
+		return label, nil
+	}
+
+	if label.CreatedBy == nil || *label.CreatedBy != actor.UserID {
+		return nil, errors.ErrForbidden
+	}
+
+	return label, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/taskflow/backend/internal/models"
+)
+
+// labelNames nombres de las etiquetas en orden
+func labelNames(labels []models.Label) []string {
+	names := []string{}
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+func TestPersonalLabelsOnlyReachTheirOwner(t *testing.T) {
+	owner := "creator"
+	labels := []models.Label{
+		{ID: "label-shared", WorkspaceID: policyWorkspace, Name: "backend"},
+		{ID: "label-personal", WorkspaceID: policyWorkspace, UserID: &owner, Name: "revisar el viernes"},
+	}
+
+	// Como loadTaskLabels: de las personales solo se cargan las de viewerID
+	taskRepo := &MockTaskRepository{
+		GetByIDFunc: func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+			task := policyTask(workspaceID)
+			for _, label := range labels {
+				if label.UserID == nil || *label.UserID == viewerID {
+					task.Labels = append(task.Labels, label)
+				}
+			}
+			return task, nil
+		},
+	}
+
+	var published []*models.TaskChange
+	changeRepo := &MockTaskChangeRepository{
+		CreateFunc: func(ctx context.Context, change *models.TaskChange) error {
+			published = append(published, change)
+			return nil
+		},
+	}
+
+	hub := NewEventHub(changeRepo, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour)
+	svc := NewTaskService(taskRepo, &MockProjectRepository{}, &MockWorkspaceRepository{}, &MockDependencyRepository{}, &MockUserRepository{}, &MockTransactor{}, hub)
+	ctx := context.Background()
+
+	tests := []struct {
+		actor string
+		want  []string
+	}{
+		{actor: "creator", want: []string{"backend", "revisar el viernes"}},
+		{actor: "assignee", want: []string{"backend"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.actor, func(t *testing.T) {
+			task, err := svc.GetTaskByID(ctx, "task-1", policyActors[tt.actor])
+			if err != nil {
+				t.Fatalf("GetTaskByID: error inesperado %v", err)
+			}
+			if got := labelNames(task.Labels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("etiquetas = %v, se esperaban %v", got, tt.want)
+			}
+		})
+	}
+
+	// El evento de un cambio del dueño llega a todo el workspace: sin su etiqueta personal
+	task, _ := taskRepo.GetByID(ctx, policyWorkspace, "task-1", owner)
+	if err := hub.Publish(ctx, models.TaskChangeUpdated, task, task, policyActors["creator"]); err != nil {
+		t.Fatalf("Publish: error inesperado %v", err)
+	}
+	if len(published) != 1 {
+		t.Fatalf("eventos publicados = %d, se esperaba 1", len(published))
+	}
+	if got := labelNames(published[0].Task.Labels); !reflect.DeepEqual(got, []string{"backend"}) {
+		t.Errorf("etiquetas del evento = %v, se esperaba solo [backend]", got)
+	}
+	if len(task.Labels) != 2 {
+		t.Errorf("Publish modificó la tarea del actor: etiquetas = %v", labelNames(task.Labels))
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/taskflow/backend/internal/errors"
 	"github.com/taskflow/backend/internal/models"
@@ -9,68 +10,780 @@ import (
 
 // MockTaskRepository es un mock para TaskRepository
 type MockTaskRepository struct {
-	CreateFunc       func(ctx context.Context, title, description, priority string, dueDate interface{}, createdBy string) (string, error)
-	GetByIDFunc      func(ctx context.Context, id string) (*models.Task, error)
-	UpdateFunc       func(ctx context.Context, id, title, description, priority string, dueDate interface{}) error
-	DeleteFunc       func(ctx context.Context, id string) error
-	GetAllFunc       func(ctx context.Context, userID, status string, page, pageSize int) ([]models.Task, int, error)
-	GetStatsFunc     func(ctx context.Context, userID string) (*models.TaskStats, error)
-	UpdateStatusFunc func(ctx context.Context, id, status string) error
-	AssignTaskFunc   func(ctx context.Context, taskID, userID string) error
+	CreateFunc            func(ctx context.Context, workspaceID, title, description, priority string, dueDate interface{}, projectID, parentID *string, createdBy string) (string, error)
+	GetByIDFunc           func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error)
+	UpdateFunc            func(ctx context.Context, workspaceID, id, title, description, priority string, dueDate interface{}, version int, actorID string) error
+	PatchFunc             func(ctx context.Context, workspaceID, id string, patch models.TaskPatch, version int, actorID string) error
+	DeleteFunc            func(ctx context.Context, workspaceID, id string, version int, actorID string) error
+	GetTrashedByIDFunc    func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error)
+	RestoreFunc           func(ctx context.Context, workspaceID, id, actorID string) error
+	PurgeFunc             func(ctx context.Context, cutoff time.Time, limit int) (int, []string, error)
+	GetAllFunc            func(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, page, pageSize int) ([]models.Task, int, error)
+	GetAfterFunc          func(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, after *models.TaskCursor, limit int) ([]models.Task, *models.TaskCursor, error)
+	CountFunc             func(ctx context.Context, workspaceID string, filter models.TaskFilter) (int, error)
+	GetStatsFunc          func(ctx context.Context, workspaceID string, filter models.TaskFilter) (*models.TaskStats, error)
+	UpdateStatusFunc      func(ctx context.Context, workspaceID, id, fromStatus, status string, version int, actorID string) error
+	AssignTaskFunc        func(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+	AddAssigneeFunc       func(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+	RemoveAssigneeFunc    func(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+	AddWatcherFunc        func(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+	RemoveWatcherFunc     func(ctx context.Context, workspaceID, taskID, userID, actorID string) error
+	SetParentFunc         func(ctx context.Context, workspaceID, id string, parentID *string, actorID string) error
+	HasAncestorFunc       func(ctx context.Context, workspaceID, taskID, ancestorID string) (bool, error)
+	CountOpenSubtasksFunc func(ctx context.Context, workspaceID, id string) (int, error)
+	GetEventsFunc         func(ctx context.Context, workspaceID, taskID string, page, pageSize int) ([]models.TaskEvent, int, error)
 }
 
-func (m *MockTaskRepository) Create(ctx context.Context, title, description, priority string, dueDate interface{}, createdBy string) (string, error) {
+func (m *MockTaskRepository) Create(ctx context.Context, workspaceID, title, description, priority string, dueDate interface{}, projectID, parentID *string, createdBy string) (string, error) {
 	if m.CreateFunc != nil {
-		return m.CreateFunc(ctx, title, description, priority, dueDate, createdBy)
+		return m.CreateFunc(ctx, workspaceID, title, description, priority, dueDate, projectID, parentID, createdBy)
 	}
 	return "task-123", nil
 }
 
-func (m *MockTaskRepository) GetByID(ctx context.Context, id string) (*models.Task, error) {
+func (m *MockTaskRepository) GetByID(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
 	if m.GetByIDFunc != nil {
-		return m.GetByIDFunc(ctx, id)
+		return m.GetByIDFunc(ctx, workspaceID, id, viewerID)
 	}
 	return nil, errors.ErrTaskNotFound
 }
 
-func (m *MockTaskRepository) Update(ctx context.Context, id, title, description, priority string, dueDate interface{}) error {
+func (m *MockTaskRepository) Update(ctx context.Context, workspaceID, id, title, description, priority string, dueDate interface{}, version int, actorID string) error {
 	if m.UpdateFunc != nil {
-		return m.UpdateFunc(ctx, id, title, description, priority, dueDate)
+		return m.UpdateFunc(ctx, workspaceID, id, title, description, priority, dueDate, version, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) Patch(ctx context.Context, workspaceID, id string, patch models.TaskPatch, version int, actorID string) error {
+	if m.PatchFunc != nil {
+		return m.PatchFunc(ctx, workspaceID, id, patch, version, actorID)
 	}
 	return nil
 }
 
-func (m *MockTaskRepository) Delete(ctx context.Context, id string) error {
+func (m *MockTaskRepository) Delete(ctx context.Context, workspaceID, id string, version int, actorID string) error {
 	if m.DeleteFunc != nil {
-		return m.DeleteFunc(ctx, id)
+		return m.DeleteFunc(ctx, workspaceID, id, version, actorID)
 	}
 	return nil
 }
 
-func (m *MockTaskRepository) GetAll(ctx context.Context, userID, status string, page, pageSize int) ([]models.Task, int, error) {
+func (m *MockTaskRepository) GetAll(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, page, pageSize int) ([]models.Task, int, error) {
 	if m.GetAllFunc != nil {
-		return m.GetAllFunc(ctx, userID, status, page, pageSize)
+		return m.GetAllFunc(ctx, workspaceID, filter, sort, page, pageSize)
 	}
 	return nil, 0, nil
 }
 
-func (m *MockTaskRepository) GetStats(ctx context.Context, userID string) (*models.TaskStats, error) {
+func (m *MockTaskRepository) GetAfter(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, after *models.TaskCursor, limit int) ([]models.Task, *models.TaskCursor, error) {
+	if m.GetAfterFunc != nil {
+		return m.GetAfterFunc(ctx, workspaceID, filter, sort, after, limit)
+	}
+	return nil, nil, nil
+}
+
+func (m *MockTaskRepository) Count(ctx context.Context, workspaceID string, filter models.TaskFilter) (int, error) {
+	if m.CountFunc != nil {
+		return m.CountFunc(ctx, workspaceID, filter)
+	}
+	return 0, nil
+}
+
+func (m *MockTaskRepository) GetStats(ctx context.Context, workspaceID string, filter models.TaskFilter) (*models.TaskStats, error) {
 	if m.GetStatsFunc != nil {
-		return m.GetStatsFunc(ctx, userID)
+		return m.GetStatsFunc(ctx, workspaceID, filter)
 	}
 	return nil, nil
 }
 
-func (m *MockTaskRepository) UpdateStatus(ctx context.Context, id, status string) error {
+func (m *MockTaskRepository) UpdateStatus(ctx context.Context, workspaceID, id, fromStatus, status string, version int, actorID string) error {
 	if m.UpdateStatusFunc != nil {
-		return m.UpdateStatusFunc(ctx, id, status)
+		return m.UpdateStatusFunc(ctx, workspaceID, id, fromStatus, status, version, actorID)
 	}
 	return nil
 }
 
-func (m *MockTaskRepository) AssignTask(ctx context.Context, taskID, userID string) error {
+func (m *MockTaskRepository) GetTrashedByID(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+	if m.GetTrashedByIDFunc != nil {
+		return m.GetTrashedByIDFunc(ctx, workspaceID, id, viewerID)
+	}
+	return nil, errors.ErrTaskNotFound
+}
+
+func (m *MockTaskRepository) Restore(ctx context.Context, workspaceID, id, actorID string) error {
+	if m.RestoreFunc != nil {
+		return m.RestoreFunc(ctx, workspaceID, id, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) Purge(ctx context.Context, cutoff time.Time, limit int) (int, []string, error) {
+	if m.PurgeFunc != nil {
+		return m.PurgeFunc(ctx, cutoff, limit)
+	}
+	return 0, nil, nil
+}
+
+func (m *MockTaskRepository) AssignTask(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
 	if m.AssignTaskFunc != nil {
-		return m.AssignTaskFunc(ctx, taskID, userID)
+		return m.AssignTaskFunc(ctx, workspaceID, taskID, userID, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) AddAssignee(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	if m.AddAssigneeFunc != nil {
+		return m.AddAssigneeFunc(ctx, workspaceID, taskID, userID, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) RemoveAssignee(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	if m.RemoveAssigneeFunc != nil {
+		return m.RemoveAssigneeFunc(ctx, workspaceID, taskID, userID, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) AddWatcher(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	if m.AddWatcherFunc != nil {
+		return m.AddWatcherFunc(ctx, workspaceID, taskID, userID, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) RemoveWatcher(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+	if m.RemoveWatcherFunc != nil {
+		return m.RemoveWatcherFunc(ctx, workspaceID, taskID, userID, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) SetParent(ctx context.Context, workspaceID, id string, parentID *string, actorID string) error {
+	if m.SetParentFunc != nil {
+		return m.SetParentFunc(ctx, workspaceID, id, parentID, actorID)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) HasAncestor(ctx context.Context, workspaceID, taskID, ancestorID string) (bool, error) {
+	if m.HasAncestorFunc != nil {
+		return m.HasAncestorFunc(ctx, workspaceID, taskID, ancestorID)
+	}
+	return false, nil
+}
+
+func (m *MockTaskRepository) CountOpenSubtasks(ctx context.Context, workspaceID, id string) (int, error) {
+	if m.CountOpenSubtasksFunc != nil {
+		return m.CountOpenSubtasksFunc(ctx, workspaceID, id)
+	}
+	return 0, nil
+}
+
+func (m *MockTaskRepository) GetEvents(ctx context.Context, workspaceID, taskID string, page, pageSize int) ([]models.TaskEvent, int, error) {
+	if m.GetEventsFunc != nil {
+		return m.GetEventsFunc(ctx, workspaceID, taskID, page, pageSize)
+	}
+	return nil, 0, nil
+}
+
+// MockUserRepository es un mock para UserRepository
+type MockUserRepository struct {
+	GetByEmailFunc            func(ctx context.Context, email string) (*models.User, error)
+	GetCredentialsByEmailFunc func(ctx context.Context, email string) (*models.User, string, error)
+	GetByIDFunc               func(ctx context.Context, id string) (*models.User, error)
+	CreateFunc                func(ctx context.Context, email, passwordHash, name string) (string, error)
+	UpdateFunc                func(ctx context.Context, user *models.User) error
+	UpdateRoleFunc            func(ctx context.Context, id, role string) error
+	GetAllUsersFunc           func(ctx context.Context) ([]*models.User, error)
+}
+
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	if m.GetByEmailFunc != nil {
+		return m.GetByEmailFunc(ctx, email)
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+func (m *MockUserRepository) GetCredentialsByEmail(ctx context.Context, email string) (*models.User, string, error) {
+	if m.GetCredentialsByEmailFunc != nil {
+		return m.GetCredentialsByEmailFunc(ctx, email)
+	}
+	return nil, "", errors.ErrUserNotFound
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, errors.ErrUserNotFound
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, email, passwordHash, name string) (string, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, email, passwordHash, name)
+	}
+	return "user-123", nil
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) UpdateRole(ctx context.Context, id, role string) error {
+	if m.UpdateRoleFunc != nil {
+		return m.UpdateRoleFunc(ctx, id, role)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	if m.GetAllUsersFunc != nil {
+		return m.GetAllUsersFunc(ctx)
+	}
+	return nil, nil
+}
+
+// MockRefreshTokenRepository es un mock para RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	CreateFunc           func(ctx context.Context, token *models.RefreshToken) error
+	GetByIDFunc          func(ctx context.Context, id string) (*models.RefreshToken, error)
+	RotateFunc           func(ctx context.Context, oldID string, newToken *models.RefreshToken) (bool, error)
+	RevokeFamilyFunc     func(ctx context.Context, familyID string) error
+	RevokeAllForUserFunc func(ctx context.Context, userID string) error
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, token)
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetByID(ctx context.Context, id string) (*models.RefreshToken, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, errors.ErrInvalidToken
+}
+
+func (m *MockRefreshTokenRepository) Rotate(ctx context.Context, oldID string, newToken *models.RefreshToken) (bool, error) {
+	if m.RotateFunc != nil {
+		return m.RotateFunc(ctx, oldID, newToken)
+	}
+	return true, nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if m.RevokeFamilyFunc != nil {
+		return m.RevokeFamilyFunc(ctx, familyID)
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	if m.RevokeAllForUserFunc != nil {
+		return m.RevokeAllForUserFunc(ctx, userID)
+	}
+	return nil
+}
+
+// MockWorkspaceRepository es un mock para WorkspaceRepository
+type MockWorkspaceRepository struct {
+	CreateFunc                  func(ctx context.Context, name, description, ownerID string) (string, error)
+	GetByIDFunc                 func(ctx context.Context, id string) (*models.Workspace, error)
+	ListForUserFunc             func(ctx context.Context, userID string) ([]models.Workspace, error)
+	UpdateFunc                  func(ctx context.Context, id, name, description string) error
+	DeleteFunc                  func(ctx context.Context, id string) error
+	GetMemberFunc               func(ctx context.Context, workspaceID, userID string) (*models.WorkspaceMember, error)
+	GetDefaultMembershipFunc    func(ctx context.Context, userID string) (*models.WorkspaceMember, error)
+	EnsureDefaultMembershipFunc func(ctx context.Context, userID, name string) (*models.WorkspaceMember, error)
+	ListMembersFunc             func(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error)
+	AddMemberFunc               func(ctx context.Context, workspaceID, userID, role string) error
+	RemoveMemberFunc            func(ctx context.Context, workspaceID, userID string) error
+	GetStatusTransitionsFunc    func(ctx context.Context, workspaceID string) (map[string][]string, error)
+	SetStatusTransitionsFunc    func(ctx context.Context, workspaceID string, transitions map[string][]string) error
+}
+
+func (m *MockWorkspaceRepository) Create(ctx context.Context, name, description, ownerID string) (string, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, name, description, ownerID)
+	}
+	return "workspace-123", nil
+}
+
+func (m *MockWorkspaceRepository) GetByID(ctx context.Context, id string) (*models.Workspace, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, errors.ErrWorkspaceNotFound
+}
+
+func (m *MockWorkspaceRepository) ListForUser(ctx context.Context, userID string) ([]models.Workspace, error) {
+	if m.ListForUserFunc != nil {
+		return m.ListForUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorkspaceRepository) Update(ctx context.Context, id, name, description string) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, name, description)
+	}
+	return nil
+}
+
+func (m *MockWorkspaceRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockWorkspaceRepository) GetMember(ctx context.Context, workspaceID, userID string) (*models.WorkspaceMember, error) {
+	if m.GetMemberFunc != nil {
+		return m.GetMemberFunc(ctx, workspaceID, userID)
+	}
+	return nil, errors.ErrMemberNotFound
+}
+
+func (m *MockWorkspaceRepository) GetDefaultMembership(ctx context.Context, userID string) (*models.WorkspaceMember, error) {
+	if m.GetDefaultMembershipFunc != nil {
+		return m.GetDefaultMembershipFunc(ctx, userID)
+	}
+	return nil, errors.ErrMemberNotFound
+}
+
+func (m *MockWorkspaceRepository) EnsureDefaultMembership(ctx context.Context, userID, name string) (*models.WorkspaceMember, error) {
+	if m.EnsureDefaultMembershipFunc != nil {
+		return m.EnsureDefaultMembershipFunc(ctx, userID, name)
+	}
+	return &models.WorkspaceMember{WorkspaceID: "workspace-123", UserID: userID, Role: models.WorkspaceRoleOwner}, nil
+}
+
+func (m *MockWorkspaceRepository) ListMembers(ctx context.Context, workspaceID string) ([]models.WorkspaceMember, error) {
+	if m.ListMembersFunc != nil {
+		return m.ListMembersFunc(ctx, workspaceID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorkspaceRepository) AddMember(ctx context.Context, workspaceID, userID, role string) error {
+	if m.AddMemberFunc != nil {
+		return m.AddMemberFunc(ctx, workspaceID, userID, role)
+	}
+	return nil
+}
+
+func (m *MockWorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	if m.RemoveMemberFunc != nil {
+		return m.RemoveMemberFunc(ctx, workspaceID, userID)
+	}
+	return nil
+}
+
+func (m *MockWorkspaceRepository) GetStatusTransitions(ctx context.Context, workspaceID string) (map[string][]string, error) {
+	if m.GetStatusTransitionsFunc != nil {
+		return m.GetStatusTransitionsFunc(ctx, workspaceID)
+	}
+	return nil, nil
+}
+
+func (m *MockWorkspaceRepository) SetStatusTransitions(ctx context.Context, workspaceID string, transitions map[string][]string) error {
+	if m.SetStatusTransitionsFunc != nil {
+		return m.SetStatusTransitionsFunc(ctx, workspaceID, transitions)
+	}
+	return nil
+}
+
+// MockProjectRepository es un mock para ProjectRepository
+type MockProjectRepository struct {
+	CreateFunc  func(ctx context.Context, workspaceID, name, description, ownerID string) (string, error)
+	GetByIDFunc func(ctx context.Context, workspaceID, id string) (*models.Project, error)
+	ListFunc    func(ctx context.Context, workspaceID string, includeArchived bool) ([]models.Project, error)
+	UpdateFunc  func(ctx context.Context, workspaceID, id, name, description string, archived bool) error
+	DeleteFunc  func(ctx context.Context, workspaceID, id string) error
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, workspaceID, name, description, ownerID string) (string, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, workspaceID, name, description, ownerID)
+	}
+	return "project-123", nil
+}
+
+func (m *MockProjectRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.Project, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, workspaceID, id)
+	}
+	return nil, errors.ErrProjectNotFound
+}
+
+func (m *MockProjectRepository) List(ctx context.Context, workspaceID string, includeArchived bool) ([]models.Project, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, workspaceID, includeArchived)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, workspaceID, id, name, description string, archived bool) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, workspaceID, id, name, description, archived)
+	}
+	return nil
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, workspaceID, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, workspaceID, id)
+	}
+	return nil
+}
+
+// MockCommentRepository es un mock para CommentRepository
+type MockCommentRepository struct {
+	CreateFunc     func(ctx context.Context, taskID string, parentID *string, authorID, body string, mentionIDs []string) (string, error)
+	GetByIDFunc    func(ctx context.Context, workspaceID, id string) (*models.Comment, error)
+	ListByTaskFunc func(ctx context.Context, taskID string, page, pageSize int) ([]models.Comment, int, error)
+	UpdateFunc     func(ctx context.Context, id, body, editedBy string, mentionIDs []string) error
+	DeleteFunc     func(ctx context.Context, id string) error
+	GetHistoryFunc func(ctx context.Context, id string) ([]models.CommentEdit, error)
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, taskID string, parentID *string, authorID, body string, mentionIDs []string) (string, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, taskID, parentID, authorID, body, mentionIDs)
+	}
+	return "comment-123", nil
+}
+
+func (m *MockCommentRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.Comment, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, workspaceID, id)
+	}
+	return nil, errors.ErrCommentNotFound
+}
+
+func (m *MockCommentRepository) ListByTask(ctx context.Context, taskID string, page, pageSize int) ([]models.Comment, int, error) {
+	if m.ListByTaskFunc != nil {
+		return m.ListByTaskFunc(ctx, taskID, page, pageSize)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockCommentRepository) Update(ctx context.Context, id, body, editedBy string, mentionIDs []string) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, body, editedBy, mentionIDs)
+	}
+	return nil
+}
+
+func (m *MockCommentRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockCommentRepository) GetHistory(ctx context.Context, id string) ([]models.CommentEdit, error) {
+	if m.GetHistoryFunc != nil {
+		return m.GetHistoryFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+// MockChecklistRepository es un mock para ChecklistRepository
+type MockChecklistRepository struct {
+	ListFunc    func(ctx context.Context, taskID string) ([]models.ChecklistItem, error)
+	GetByIDFunc func(ctx context.Context, taskID, id string) (*models.ChecklistItem, error)
+	CreateFunc  func(ctx context.Context, workspaceID, taskID, text string, position *int, actorID string) (string, error)
+	UpdateFunc  func(ctx context.Context, workspaceID, taskID, id, text string, done bool, position int, actorID string) error
+	DeleteFunc  func(ctx context.Context, workspaceID, taskID, id, actorID string) error
+}
+
+func (m *MockChecklistRepository) List(ctx context.Context, taskID string) ([]models.ChecklistItem, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, taskID)
+	}
+	return nil, nil
+}
+
+func (m *MockChecklistRepository) GetByID(ctx context.Context, taskID, id string) (*models.ChecklistItem, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, taskID, id)
+	}
+	return nil, errors.ErrChecklistItemNotFound
+}
+
+func (m *MockChecklistRepository) Create(ctx context.Context, workspaceID, taskID, text string, position *int, actorID string) (string, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, workspaceID, taskID, text, position, actorID)
+	}
+	return "item-123", nil
+}
+
+func (m *MockChecklistRepository) Update(ctx context.Context, workspaceID, taskID, id, text string, done bool, position int, actorID string) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, workspaceID, taskID, id, text, done, position, actorID)
+	}
+	return nil
+}
+
+func (m *MockChecklistRepository) Delete(ctx context.Context, workspaceID, taskID, id, actorID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, workspaceID, taskID, id, actorID)
+	}
+	return nil
+}
+
+// MockDependencyRepository es un mock para DependencyRepository
+type MockDependencyRepository struct {
+	LockGraphFunc         func(ctx context.Context, workspaceID string) error
+	AddFunc               func(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error
+	RemoveFunc            func(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error
+	ListEdgesFunc         func(ctx context.Context, workspaceID string) ([]models.TaskDependencyEdge, error)
+	CountOpenBlockersFunc func(ctx context.Context, workspaceID, taskID string) (int, error)
+}
+
+func (m *MockDependencyRepository) LockGraph(ctx context.Context, workspaceID string) error {
+	if m.LockGraphFunc != nil {
+		return m.LockGraphFunc(ctx, workspaceID)
+	}
+	return nil
+}
+
+func (m *MockDependencyRepository) Add(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error {
+	if m.AddFunc != nil {
+		return m.AddFunc(ctx, workspaceID, blockerID, blockedID, actorID)
+	}
+	return nil
+}
+
+func (m *MockDependencyRepository) Remove(ctx context.Context, workspaceID, blockerID, blockedID, actorID string) error {
+	if m.RemoveFunc != nil {
+		return m.RemoveFunc(ctx, workspaceID, blockerID, blockedID, actorID)
 	}
 	return nil
 }
+
+func (m *MockDependencyRepository) ListEdges(ctx context.Context, workspaceID string) ([]models.TaskDependencyEdge, error) {
+	if m.ListEdgesFunc != nil {
+		return m.ListEdgesFunc(ctx, workspaceID)
+	}
+	return nil, nil
+}
+
+func (m *MockDependencyRepository) CountOpenBlockers(ctx context.Context, workspaceID, taskID string) (int, error) {
+	if m.CountOpenBlockersFunc != nil {
+		return m.CountOpenBlockersFunc(ctx, workspaceID, taskID)
+	}
+	return 0, nil
+}
+
+// MockRecurrenceRepository es un mock para RecurrenceRepository
+type MockRecurrenceRepository struct {
+	GetByIDFunc          func(ctx context.Context, workspaceID, id string) (*models.TaskRecurrence, error)
+	CreateFunc           func(ctx context.Context, rule *models.TaskRecurrence, actorID string) (string, error)
+	UpdateFunc           func(ctx context.Context, rule *models.TaskRecurrence) error
+	DeleteFunc           func(ctx context.Context, workspaceID, id, actorID string) error
+	ListDueFunc          func(ctx context.Context, now time.Time, limit int) ([]models.TaskRecurrence, error)
+	CreateOccurrenceFunc func(ctx context.Context, rule *models.TaskRecurrence, dueDate time.Time) (string, error)
+	DeactivateFunc       func(ctx context.Context, id string) error
+}
+
+func (m *MockRecurrenceRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.TaskRecurrence, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, workspaceID, id)
+	}
+	return nil, errors.ErrRecurrenceNotFound
+}
+
+func (m *MockRecurrenceRepository) Create(ctx context.Context, rule *models.TaskRecurrence, actorID string) (string, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, rule, actorID)
+	}
+	return "recurrence-123", nil
+}
+
+func (m *MockRecurrenceRepository) Update(ctx context.Context, rule *models.TaskRecurrence) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, rule)
+	}
+	return nil
+}
+
+func (m *MockRecurrenceRepository) Delete(ctx context.Context, workspaceID, id, actorID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, workspaceID, id, actorID)
+	}
+	return nil
+}
+
+func (m *MockRecurrenceRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]models.TaskRecurrence, error) {
+	if m.ListDueFunc != nil {
+		return m.ListDueFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockRecurrenceRepository) CreateOccurrence(ctx context.Context, rule *models.TaskRecurrence, dueDate time.Time) (string, error) {
+	if m.CreateOccurrenceFunc != nil {
+		return m.CreateOccurrenceFunc(ctx, rule, dueDate)
+	}
+	return "task-123", nil
+}
+
+func (m *MockRecurrenceRepository) Deactivate(ctx context.Context, id string) error {
+	if m.DeactivateFunc != nil {
+		return m.DeactivateFunc(ctx, id)
+	}
+	return nil
+}
+
+// MockLabelRepository es un mock para LabelRepository
+type MockLabelRepository struct {
+	ListFunc    func(ctx context.Context, workspaceID, userID string) ([]models.Label, error)
+	GetByIDFunc func(ctx context.Context, workspaceID, id string) (*models.Label, error)
+	CreateFunc  func(ctx context.Context, workspaceID string, userID *string, name, color, createdBy string) (string, error)
+	UpdateFunc  func(ctx context.Context, workspaceID, id, name, color string) error
+	DeleteFunc  func(ctx context.Context, workspaceID, id string) error
+	AttachFunc  func(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error
+	DetachFunc  func(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error
+}
+
+func (m *MockLabelRepository) List(ctx context.Context, workspaceID, userID string) ([]models.Label, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, workspaceID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockLabelRepository) GetByID(ctx context.Context, workspaceID, id string) (*models.Label, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, workspaceID, id)
+	}
+	return nil, errors.ErrLabelNotFound
+}
+
+func (m *MockLabelRepository) Create(ctx context.Context, workspaceID string, userID *string, name, color, createdBy string) (string, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, workspaceID, userID, name, color, createdBy)
+	}
+	return "label-123", nil
+}
+
+func (m *MockLabelRepository) Update(ctx context.Context, workspaceID, id, name, color string) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, workspaceID, id, name, color)
+	}
+	return nil
+}
+
+func (m *MockLabelRepository) Delete(ctx context.Context, workspaceID, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, workspaceID, id)
+	}
+	return nil
+}
+
+func (m *MockLabelRepository) Attach(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error {
+	if m.AttachFunc != nil {
+		return m.AttachFunc(ctx, workspaceID, taskID, label, actorID)
+	}
+	return nil
+}
+
+func (m *MockLabelRepository) Detach(ctx context.Context, workspaceID, taskID string, label *models.Label, actorID string) error {
+	if m.DetachFunc != nil {
+		return m.DetachFunc(ctx, workspaceID, taskID, label, actorID)
+	}
+	return nil
+}
+
+// MockAttachmentRepository es un mock para AttachmentRepository
+type MockAttachmentRepository struct {
+	ListByTaskFunc func(ctx context.Context, taskID string) ([]models.Attachment, error)
+	GetByIDFunc    func(ctx context.Context, taskID, id string) (*models.Attachment, error)
+	CreateFunc     func(ctx context.Context, workspaceID string, attachment *models.Attachment) error
+	DeleteFunc     func(ctx context.Context, workspaceID, taskID, id, actorID string) error
+}
+
+func (m *MockAttachmentRepository) ListByTask(ctx context.Context, taskID string) ([]models.Attachment, error) {
+	if m.ListByTaskFunc != nil {
+		return m.ListByTaskFunc(ctx, taskID)
+	}
+	return nil, nil
+}
+
+func (m *MockAttachmentRepository) GetByID(ctx context.Context, taskID, id string) (*models.Attachment, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, taskID, id)
+	}
+	return nil, errors.ErrAttachmentNotFound
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, workspaceID string, attachment *models.Attachment) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, workspaceID, attachment)
+	}
+	return nil
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, workspaceID, taskID, id, actorID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, workspaceID, taskID, id, actorID)
+	}
+	return nil
+}
+
+// MockTaskChangeRepository es un mock para TaskChangeRepository
+type MockTaskChangeRepository struct {
+	CreateFunc       func(ctx context.Context, change *models.TaskChange) error
+	GetByIDFunc      func(ctx context.Context, id int64) (*models.TaskChange, error)
+	ListAfterFunc    func(ctx context.Context, workspaceID string, afterID int64, window time.Duration, limit int) ([]models.TaskChange, error)
+	DeleteBeforeFunc func(ctx context.Context, before time.Time) (int, error)
+}
+
+func (m *MockTaskChangeRepository) Create(ctx context.Context, change *models.TaskChange) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, change)
+	}
+	return nil
+}
+
+func (m *MockTaskChangeRepository) GetByID(ctx context.Context, id int64) (*models.TaskChange, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, errors.NewAppError(404, "Evento no encontrado", "")
+}
+
+func (m *MockTaskChangeRepository) ListAfter(ctx context.Context, workspaceID string, afterID int64, window time.Duration, limit int) ([]models.TaskChange, error) {
+	if m.ListAfterFunc != nil {
+		return m.ListAfterFunc(ctx, workspaceID, afterID, window, limit)
+	}
+	return []models.TaskChange{}, nil
+}
+
+func (m *MockTaskChangeRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	if m.DeleteBeforeFunc != nil {
+		return m.DeleteBeforeFunc(ctx, before)
+	}
+	return 0, nil
+}
+
+// MockTransactor es un mock para Transactor; sin WithinTxFunc ejecuta fn directamente
+type MockTransactor struct {
+	WithinTxFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.WithinTxFunc != nil {
+		return m.WithinTxFunc(ctx, fn)
+	}
+	return fn(ctx)
+}
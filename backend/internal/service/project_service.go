@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// ProjectService maneja la lógica de negocio de proyectos
+type ProjectService struct {
+	projectRepo domain.ProjectRepository
+	taskRepo    domain.TaskRepository
+}
+
+// NewProjectService crea una nueva instancia de ProjectService
+func NewProjectService(projectRepo domain.ProjectRepository, taskRepo domain.TaskRepository) *ProjectService {
+	return &ProjectService{
+		projectRepo: projectRepo,
+		taskRepo:    taskRepo,
+	}
+}
+
+// CreateProject crea un proyecto en el workspace del actor
+func (s *ProjectService) CreateProject(ctx context.Context, req *models.CreateProjectRequest, actor *models.Actor) (*models.Project, error) {
+	projectID, err := s.projectRepo.Create(ctx, actor.WorkspaceID, req.Name, req.Description, actor.UserID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al crear proyecto: %v", err))
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, actor.WorkspaceID, projectID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener proyecto: %v", err))
+	}
+
+	return project, nil
+}
+
+// ListProjects obtiene los proyectos del workspace del actor
+func (s *ProjectService) ListProjects(ctx context.Context, actor *models.Actor, includeArchived bool) ([]models.Project, error) {
+	projects, err := s.projectRepo.List(ctx, actor.WorkspaceID, includeArchived)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener proyectos: %v", err))
+	}
+	return projects, nil
+}
+
+// GetProject obtiene un proyecto del workspace del actor
+func (s *ProjectService) GetProject(ctx context.Context, projectID string, actor *models.Actor) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(ctx, actor.WorkspaceID, projectID)
+	if err != nil {
+		return nil, errors.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// UpdateProject actualiza o archiva un proyecto (dueño del proyecto o administradores)
+func (s *ProjectService) UpdateProject(ctx context.Context, projectID string, req *models.UpdateProjectRequest, actor *models.Actor) (*models.Project, error) {
+	project, err := s.GetProject(ctx, projectID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canManageProject(project, actor) {
+		return nil, errors.ErrForbidden
+	}
+
+	name := project.Name
+	if req.Name != nil {
+		name = *req.Name
+	}
+
+	description := project.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	archived := project.Archived
+	if req.Archived != nil {
+		archived = *req.Archived
+	}
+
+	if err := s.projectRepo.Update(ctx, actor.WorkspaceID, projectID, name, description, archived); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al actualizar proyecto: %v", err))
+	}
+
+	return s.projectRepo.GetByID(ctx, actor.WorkspaceID, projectID)
+}
+
+// DeleteProject elimina un proyecto (dueño del proyecto o administradores)
+func (s *ProjectService) DeleteProject(ctx context.Context, projectID string, actor *models.Actor) error {
+	project, err := s.GetProject(ctx, projectID, actor)
+	if err != nil {
+		return err
+	}
+
+	if !canManageProject(project, actor) {
+		return errors.ErrForbidden
+	}
+
+	if err := s.projectRepo.Delete(ctx, actor.WorkspaceID, projectID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar proyecto: %v", err))
+	}
+
+	return nil
+}
+
+// GetProjectTasks lista las tareas de un proyecto con paginación. Los administradores
+// ven todas; el resto solo las tareas que crearon o tienen asignadas
+func (s *ProjectService) GetProjectTasks(ctx context.Context, projectID, status string, page, pageSize int, actor *models.Actor) (*models.TasksListResponse, error) {
+	if _, err := s.GetProject(ctx, projectID, actor); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = normalizePagination(page, pageSize)
+
+	tasks, total, err := s.taskRepo.GetAll(ctx, actor.WorkspaceID, projectTaskFilter(projectID, status, actor), models.TaskSort{}, page, pageSize)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener tareas: %v", err))
+	}
+
+	return newTasksListResponse(tasks, total, page, pageSize), nil
+}
+
+// GetProjectStats obtiene estadísticas de las tareas de un proyecto visibles para el actor
+func (s *ProjectService) GetProjectStats(ctx context.Context, projectID string, actor *models.Actor) (*models.TaskStats, error) {
+	if _, err := s.GetProject(ctx, projectID, actor); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.taskRepo.GetStats(ctx, actor.WorkspaceID, projectTaskFilter(projectID, "", actor))
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener estadísticas: %v", err))
+	}
+
+	return stats, nil
+}
+
+// projectTaskFilter construye el filtro de tareas de un proyecto según la visibilidad del actor
+func projectTaskFilter(projectID, status string, actor *models.Actor) models.TaskFilter {
+	filter := models.TaskFilter{ProjectID: projectID, Status: status, LabelOwner: actor.UserID}
+	if !actor.IsAdmin() && !actor.IsWorkspaceAdmin() {
+		filter.UserID = actor.UserID
+	}
+	return filter
+}
+
+// canManageProject indica si el actor puede modificar o eliminar el proyecto
+func canManageProject(project *models.Project, actor *models.Actor) bool {
+	return project.OwnerID == actor.UserID || actor.IsAdmin() || actor.IsWorkspaceAdmin()
+}
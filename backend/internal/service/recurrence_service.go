@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/utils/validation"
+)
+
+// recurrenceBatchSize cantidad máxima de reglas procesadas por pasada del generador
+const recurrenceBatchSize = 100
+
+// RecurrenceService maneja la lógica de negocio de las tareas repetitivas
+type RecurrenceService struct {
+	recurrenceRepo domain.RecurrenceRepository
+	taskRepo       domain.TaskRepository
+	transactor     domain.Transactor
+	events         *EventHub
+}
+
+// NewRecurrenceService crea una nueva instancia de RecurrenceService
+func NewRecurrenceService(recurrenceRepo domain.RecurrenceRepository, taskRepo domain.TaskRepository, transactor domain.Transactor, events *EventHub) *RecurrenceService {
+	return &RecurrenceService{
+		recurrenceRepo: recurrenceRepo,
+		taskRepo:       taskRepo,
+		transactor:     transactor,
+		events:         events,
+	}
+}
+
+// GetRecurrence obtiene la regla de repetición de la que forma parte la tarea
+func (s *RecurrenceService) GetRecurrence(ctx context.Context, taskID string, actor *models.Actor) (*models.TaskRecurrence, error) {
+	task, err := s.getTask(ctx, taskID, actor, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.RecurrenceID == nil {
+		return nil, errors.ErrRecurrenceNotFound
+	}
+
+	return s.getRule(ctx, actor.WorkspaceID, *task.RecurrenceID)
+}
+
+// SetRecurrence define o reemplaza la regla de repetición de la tarea. Si la tarea
+// aún no se repite, su fecha de vencimiento es el inicio de la serie
+func (s *RecurrenceService) SetRecurrence(ctx context.Context, taskID string, req *models.SetRecurrenceRequest, actor *models.Actor) (*models.TaskRecurrence, error) {
+	task, err := s.getTask(ctx, taskID, actor, TaskActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &models.TaskRecurrence{WorkspaceID: actor.WorkspaceID, TaskID: task.ID}
+	if task.RecurrenceID != nil {
+		rule, err = s.getRule(ctx, actor.WorkspaceID, *task.RecurrenceID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if task.DueDate == nil {
+			return nil, errors.NewAppError(422, "La tarea necesita fecha de vencimiento para repetirse", "")
+		}
+		rule.StartsAt = *task.DueDate
+		rule.LastDueAt = *task.DueDate
+		rule.Occurrences = 1
+	}
+
+	if err := applyRecurrenceRequest(rule, req); err != nil {
+		return nil, err
+	}
+
+	// Editar la regla reactiva una serie terminada
+	rule.Active = true
+
+	if rule.ID == "" {
+		rule.ID, err = s.recurrenceRepo.Create(ctx, rule, actor.UserID)
+	} else {
+		err = s.recurrenceRepo.Update(ctx, rule)
+	}
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al guardar regla de repetición: %v", err))
+	}
+
+	return s.getRule(ctx, actor.WorkspaceID, rule.ID)
+}
+
+// DeleteRecurrence detiene la serie de la tarea. Las ocurrencias ya creadas se conservan
+func (s *RecurrenceService) DeleteRecurrence(ctx context.Context, taskID string, actor *models.Actor) error {
+	task, err := s.getTask(ctx, taskID, actor, TaskActionUpdate)
+	if err != nil {
+		return err
+	}
+
+	if task.RecurrenceID == nil {
+		return errors.ErrRecurrenceNotFound
+	}
+
+	if err := s.recurrenceRepo.Delete(ctx, actor.WorkspaceID, *task.RecurrenceID, actor.UserID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar regla de repetición: %v", err))
+	}
+
+	return nil
+}
+
+// GenerateDue crea la siguiente ocurrencia de cada regla cuya última ocurrencia ya
+// venció o se cerró, y desactiva las series terminadas. Retorna cuántas tareas creó.
+// Es idempotente: repetir la pasada no genera duplicados. Cada ocurrencia se publica
+// como task.created igual que las tareas creadas con CreateTask
+func (s *RecurrenceService) GenerateDue(ctx context.Context, now time.Time) (int, error) {
+	rules, err := s.recurrenceRepo.ListDue(ctx, now, recurrenceBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range rules {
+		rule := &rules[i]
+
+		next := nextOccurrence(rule)
+		if next == nil {
+			if err := s.recurrenceRepo.Deactivate(ctx, rule.ID); err != nil {
+				log.Printf("🔴 ERROR al finalizar regla de repetición %s: %v\n", rule.ID, err)
+			}
+			continue
+		}
+
+		// La ocurrencia y su evento se confirman juntos
+		var taskID string
+		err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			taskID, err = s.recurrenceRepo.CreateOccurrence(ctx, rule, *next)
+			if err != nil || taskID == "" {
+				return err
+			}
+			return s.publishOccurrence(ctx, rule.WorkspaceID, taskID)
+		})
+		if err != nil {
+			log.Printf("🔴 ERROR al generar ocurrencia de la regla %s: %v\n", rule.ID, err)
+			continue
+		}
+		if taskID != "" {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// publishOccurrence publica la ocurrencia creada. El actor es el creador de la
+// plantilla, que también figura como autor en el historial de la tarea. El evento
+// llega a todo el workspace, así que no lleva etiquetas personales
+func (s *RecurrenceService) publishOccurrence(ctx context.Context, workspaceID, taskID string) error {
+	task, err := s.taskRepo.GetByID(ctx, workspaceID, taskID, "")
+	if err != nil {
+		return fmt.Errorf("error al obtener ocurrencia %s para publicarla: %w", taskID, err)
+	}
+
+	actor := &models.Actor{UserID: task.CreatedBy, WorkspaceID: task.WorkspaceID}
+	return s.events.Publish(ctx, models.TaskChangeCreated, task, nil, actor)
+}
+
+// getTask obtiene la tarea y verifica que el actor pueda realizar la acción
+func (s *RecurrenceService) getTask(ctx context.Context, taskID string, actor *models.Actor, action TaskAction) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, action); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// getRule obtiene la regla con su próximo vencimiento calculado
+func (s *RecurrenceService) getRule(ctx context.Context, workspaceID, id string) (*models.TaskRecurrence, error) {
+	rule, err := s.recurrenceRepo.GetByID(ctx, workspaceID, id)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener regla de repetición: %v", err))
+	}
+
+	if rule.Active {
+		rule.NextDueAt = nextOccurrence(rule)
+	}
+
+	return rule, nil
+}
+
+// applyRecurrenceRequest valida el request y lo aplica sobre la regla
+func applyRecurrenceRequest(rule *models.TaskRecurrence, req *models.SetRecurrenceRequest) error {
+	if len(req.Weekdays) > 0 && req.Frequency != models.RecurrenceWeekly {
+		return errors.NewAppError(422, "weekdays solo aplica a la frecuencia weekly", "")
+	}
+	if req.MonthDay != nil && req.Frequency != models.RecurrenceMonthly {
+		return errors.NewAppError(422, "month_day solo aplica a la frecuencia monthly", "")
+	}
+
+	var endsAt *time.Time
+	if req.EndsAt != nil && *req.EndsAt != "" {
+		t, err := validation.ParseDate(*req.EndsAt, true)
+		if err != nil {
+			return errors.NewAppError(422, "ends_at inválido", err.Error())
+		}
+		if t.Before(rule.StartsAt) {
+			return errors.NewAppError(422, "ends_at no puede ser anterior al inicio de la serie", "")
+		}
+		endsAt = &t
+	}
+
+	if req.MaxOccurrences != nil && *req.MaxOccurrences < rule.Occurrences {
+		return errors.NewAppError(
+			422,
+			"max_occurrences es menor que las ocurrencias ya generadas",
+			fmt.Sprintf("ocurrencias generadas: %d", rule.Occurrences),
+		)
+	}
+
+	weekdays := dedupeWeekdays(req.Weekdays)
+
+	rule.Frequency = req.Frequency
+	rule.Interval = req.Interval
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	rule.Weekdays = weekdays
+	rule.MonthDay = req.MonthDay
+	rule.EndsAt = endsAt
+	rule.MaxOccurrences = req.MaxOccurrences
+
+	return nil
+}
+
+// dedupeWeekdays elimina días repetidos y los ordena
+func dedupeWeekdays(weekdays []int) []int {
+	seen := make(map[int]bool, len(weekdays))
+	days := make([]int, 0, len(weekdays))
+	for _, day := range weekdays {
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+	return days
+}
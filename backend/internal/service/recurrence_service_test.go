@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taskflow/backend/internal/models"
+)
+
+func TestGenerateDuePublishesCreatedOccurrences(t *testing.T) {
+	lastDue := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	rules := []models.TaskRecurrence{
+		{ID: "rule-new", WorkspaceID: "ws-1", TaskID: "template-1", Frequency: models.RecurrenceDaily, Interval: 1, LastDueAt: lastDue, Active: true},
+		{ID: "rule-existing", WorkspaceID: "ws-1", TaskID: "template-2", Frequency: models.RecurrenceDaily, Interval: 1, LastDueAt: lastDue, Active: true},
+	}
+
+	recurrenceRepo := &MockRecurrenceRepository{
+		ListDueFunc: func(ctx context.Context, now time.Time, limit int) ([]models.TaskRecurrence, error) {
+			return rules, nil
+		},
+		CreateOccurrenceFunc: func(ctx context.Context, rule *models.TaskRecurrence, dueDate time.Time) (string, error) {
+			// La ocurrencia de rule-existing ya existía: no hay tarea nueva que publicar
+			if rule.ID == "rule-existing" {
+				return "", nil
+			}
+			return "occurrence-1", nil
+		},
+	}
+	taskRepo := &MockTaskRepository{
+		GetByIDFunc: func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+			return &models.Task{ID: id, WorkspaceID: workspaceID, Title: "Tarea diaria", CreatedBy: "creator"}, nil
+		},
+	}
+
+	var published []*models.TaskChange
+	changeRepo := &MockTaskChangeRepository{
+		CreateFunc: func(ctx context.Context, change *models.TaskChange) error {
+			published = append(published, change)
+			return nil
+		},
+	}
+
+	svc := NewRecurrenceService(recurrenceRepo, taskRepo, &MockTransactor{}, NewEventHub(changeRepo, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour))
+
+	created, err := svc.GenerateDue(context.Background(), lastDue.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateDue: error inesperado %v", err)
+	}
+	if created != 1 {
+		t.Errorf("created = %d, se esperaba 1", created)
+	}
+
+	if len(published) != 1 {
+		t.Fatalf("eventos publicados = %d, se esperaba 1", len(published))
+	}
+	change := published[0]
+	if change.Type != models.TaskChangeCreated || change.TaskID != "occurrence-1" || change.WorkspaceID != "ws-1" {
+		t.Errorf("evento = %s %s en %s, se esperaba task.created occurrence-1 en ws-1", change.Type, change.TaskID, change.WorkspaceID)
+	}
+	if change.ActorID != "creator" {
+		t.Errorf("actor del evento = %q, se esperaba el creador de la plantilla", change.ActorID)
+	}
+}
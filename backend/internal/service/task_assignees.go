@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// AddAssignee agrega un responsable a la tarea. Si no tenía responsable, el nuevo
+// pasa a ser el principal (assigned_to)
+func (s *TaskService) AddAssignee(ctx context.Context, taskID string, req *models.AddAssigneeRequest, actor *models.Actor) (*models.Task, error) {
+	task, err := s.getTaskFor(ctx, taskID, actor, TaskActionAssign)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAssignee(ctx, actor.WorkspaceID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, models.TaskChangeAssigned, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.AddAssignee(ctx, actor.WorkspaceID, taskID, req.UserID, actor.UserID); err != nil {
+			return errors.NewInternalServerError(fmt.Sprintf("error al agregar responsable: %v", err))
+		}
+		return nil
+	})
+}
+
+// RemoveAssignee quita un responsable de la tarea. Si era el principal, lo reemplaza
+// el responsable restante más antiguo
+func (s *TaskService) RemoveAssignee(ctx context.Context, taskID, userID string, actor *models.Actor) (*models.Task, error) {
+	task, err := s.getTaskFor(ctx, taskID, actor, TaskActionAssign)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, models.TaskChangeAssigned, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.RemoveAssignee(ctx, actor.WorkspaceID, taskID, userID, actor.UserID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al quitar responsable: %v", err))
+		}
+		return nil
+	})
+}
+
+// AssignToMe agrega al actor como responsable; pasa a ser el principal si la tarea
+// no tenía. Requiere poder asignar la tarea, como agregar a cualquier otro responsable
+func (s *TaskService) AssignToMe(ctx context.Context, taskID string, actor *models.Actor) (*models.Task, error) {
+	task, err := s.getTaskFor(ctx, taskID, actor, TaskActionAssign)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAssignee(ctx, actor.WorkspaceID, actor.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, models.TaskChangeAssigned, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.AddAssignee(ctx, actor.WorkspaceID, taskID, actor.UserID, actor.UserID); err != nil {
+			return errors.NewInternalServerError(fmt.Sprintf("error al asignar tarea: %v", err))
+		}
+		return nil
+	})
+}
+
+// UnassignMe quita al actor de los responsables de la tarea
+func (s *TaskService) UnassignMe(ctx context.Context, taskID string, actor *models.Actor) (*models.Task, error) {
+	task, err := s.getTaskFor(ctx, taskID, actor, TaskActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, models.TaskChangeAssigned, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.RemoveAssignee(ctx, actor.WorkspaceID, taskID, actor.UserID, actor.UserID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al desasignar tarea: %v", err))
+		}
+		return nil
+	})
+}
+
+// AddWatcher agrega un seguidor a la tarea. Cualquiera que pueda verla puede
+// seguirla; agregar a otro usuario requiere poder asignarla
+func (s *TaskService) AddWatcher(ctx context.Context, taskID string, req *models.AddWatcherRequest, actor *models.Actor) (*models.Task, error) {
+	userID := actor.UserID
+	if req.UserID != nil {
+		userID = *req.UserID
+	}
+
+	task, err := s.getTaskFor(ctx, taskID, actor, watcherAction(userID, actor))
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != actor.UserID {
+		if _, err := s.getWorkspaceUser(ctx, actor.WorkspaceID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.applyChange(ctx, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.AddWatcher(ctx, actor.WorkspaceID, taskID, userID, actor.UserID); err != nil {
+			return errors.NewInternalServerError(fmt.Sprintf("error al agregar seguidor: %v", err))
+		}
+		return nil
+	})
+}
+
+// RemoveWatcher quita un seguidor de la tarea. Dejar de seguirla solo requiere
+// verla; quitar a otro usuario requiere poder asignarla
+func (s *TaskService) RemoveWatcher(ctx context.Context, taskID, userID string, actor *models.Actor) (*models.Task, error) {
+	task, err := s.getTaskFor(ctx, taskID, actor, watcherAction(userID, actor))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.RemoveWatcher(ctx, actor.WorkspaceID, taskID, userID, actor.UserID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al quitar seguidor: %v", err))
+		}
+		return nil
+	})
+}
+
+// watcherAction acción requerida para cambiar los seguidores: la propia
+// suscripción solo requiere ver la tarea
+func watcherAction(userID string, actor *models.Actor) TaskAction {
+	if userID == actor.UserID {
+		return TaskActionView
+	}
+	return TaskActionAssign
+}
+
+// getTaskFor obtiene la tarea y verifica que el actor pueda realizar la acción
+func (s *TaskService) getTaskFor(ctx context.Context, taskID string, actor *models.Actor, action TaskAction) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, action); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// getWorkspaceUser obtiene el usuario y verifica que pueda acceder a las tareas del
+// workspace: 404 si no existe y 422 si no es miembro
+func (s *TaskService) getWorkspaceUser(ctx context.Context, workspaceID, userID string) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if _, err := s.workspaceRepo.GetMember(ctx, workspaceID, userID); err != nil {
+		return nil, errors.NewAppError(422, "El usuario no es miembro del workspace", "")
+	}
+
+	return user, nil
+}
+
+// checkAssignee verifica que el usuario pueda ser responsable de una tarea del
+// workspace. Los viewers no pueden editar tareas, por lo que tampoco ser responsables
+func (s *TaskService) checkAssignee(ctx context.Context, workspaceID, userID string) error {
+	user, err := s.getWorkspaceUser(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role == models.RoleViewer {
+		return errors.NewAppError(422, "El usuario tiene rol de solo lectura y no puede ser responsable", "")
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// BulkTaskService aplica una operación a varias tareas con las mismas reglas que
+// TaskService y LabelService, incluida la autorización de cada tarea
+type BulkTaskService struct {
+	taskService  *TaskService
+	labelService *LabelService
+	transactor   domain.Transactor
+	maxTasks     int
+}
+
+// NewBulkTaskService crea una nueva instancia de BulkTaskService
+func NewBulkTaskService(taskService *TaskService, labelService *LabelService, transactor domain.Transactor, maxTasks int) *BulkTaskService {
+	return &BulkTaskService{
+		taskService:  taskService,
+		labelService: labelService,
+		transactor:   transactor,
+		maxTasks:     maxTasks,
+	}
+}
+
+// Apply aplica la operación a cada tarea y retorna el resultado de cada una. En modo
+// atómico, la primera tarea que falla revierte el lote completo, el resto de las
+// tareas se informan como no aplicadas y la respuesta se marca como revertida
+func (s *BulkTaskService) Apply(ctx context.Context, req *models.BulkTaskRequest, actor *models.Actor) (*models.BulkTaskResponse, error) {
+	if len(req.TaskIDs) > s.maxTasks {
+		return nil, errors.NewBadRequest(fmt.Sprintf("se permiten como máximo %d tareas por operación", s.maxTasks))
+	}
+
+	if err := checkBulkTaskRequest(req); err != nil {
+		return nil, err
+	}
+
+	taskIDs := uniqueTaskIDs(req.TaskIDs)
+
+	if !req.Atomic {
+		results := make([]models.BulkTaskResult, len(taskIDs))
+		for i, taskID := range taskIDs {
+			results[i] = bulkTaskResult(taskID, s.apply(ctx, req, taskID, actor))
+		}
+		return newBulkTaskResponse(results), nil
+	}
+
+	// Las tareas se procesan en orden de ID para que dos lotes que comparten tareas
+	// las bloqueen en el mismo orden
+	ordered := append([]string(nil), taskIDs...)
+	sort.Strings(ordered)
+
+	var failedID string
+	var failure error
+	err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		for _, taskID := range ordered {
+			if err := s.apply(ctx, req, taskID, actor); err != nil {
+				failedID, failure = taskID, err
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && failure == nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al aplicar operación masiva: %v", err))
+	}
+
+	results := make([]models.BulkTaskResult, len(taskIDs))
+	for i, taskID := range taskIDs {
+		switch {
+		case failure == nil:
+			results[i] = bulkTaskResult(taskID, nil)
+		case taskID == failedID:
+			results[i] = bulkTaskResult(taskID, failure)
+		default:
+			results[i] = models.BulkTaskResult{
+				TaskID: taskID,
+				Code:   409,
+				Error:  fmt.Sprintf("No se aplicó: la operación falló en la tarea %s", failedID),
+			}
+		}
+	}
+
+	resp := newBulkTaskResponse(results)
+	resp.RolledBack = failure != nil
+	return resp, nil
+}
+
+// apply aplica la operación a una tarea
+func (s *BulkTaskService) apply(ctx context.Context, req *models.BulkTaskRequest, taskID string, actor *models.Actor) error {
+	var err error
+	switch req.Operation {
+	case models.BulkOpUpdateStatus:
+		statusReq := &models.UpdateTaskStatusRequest{Status: req.Status, AllowOpenSubtasks: req.AllowOpenSubtasks}
+		_, _, err = s.taskService.UpdateTaskStatus(ctx, taskID, statusReq, nil, actor)
+	case models.BulkOpAssign:
+		_, err = s.taskService.AssignTask(ctx, taskID, &models.AssignTaskRequest{AssignedTo: req.AssignedTo}, actor)
+	case models.BulkOpSetPriority:
+		_, err = s.taskService.PatchTask(ctx, taskID, models.TaskPatch{"priority": req.Priority}, nil, actor)
+	case models.BulkOpDelete:
+		err = s.taskService.DeleteTask(ctx, taskID, nil, actor)
+	case models.BulkOpAddLabel:
+		_, err = s.labelService.AttachLabel(ctx, taskID, &models.AttachLabelRequest{LabelID: req.LabelID}, actor)
+	default:
+		err = errors.NewBadRequest(fmt.Sprintf("operación desconocida: %s", req.Operation))
+	}
+	return err
+}
+
+// checkBulkTaskRequest verifica que el request incluya el campo que usa su operación
+func checkBulkTaskRequest(req *models.BulkTaskRequest) error {
+	switch {
+	case req.Operation == models.BulkOpUpdateStatus && req.Status == "":
+		return errors.NewBadRequest("status es requerido para update_status")
+	case req.Operation == models.BulkOpSetPriority && req.Priority == "":
+		return errors.NewBadRequest("priority es requerido para set_priority")
+	case req.Operation == models.BulkOpAddLabel && req.LabelID == "":
+		return errors.NewBadRequest("label_id es requerido para add_label")
+	}
+	return nil
+}
+
+// uniqueTaskIDs quita los IDs repetidos conservando el orden
+func uniqueTaskIDs(taskIDs []string) []string {
+	seen := make(map[string]bool, len(taskIDs))
+	unique := make([]string, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		if !seen[taskID] {
+			seen[taskID] = true
+			unique = append(unique, taskID)
+		}
+	}
+	return unique
+}
+
+// bulkTaskResult construye el resultado de una tarea a partir del error de su operación
+func bulkTaskResult(taskID string, err error) models.BulkTaskResult {
+	if err == nil {
+		return models.BulkTaskResult{TaskID: taskID, Success: true, Code: 200}
+	}
+	if appErr, ok := err.(*errors.AppError); ok {
+		return models.BulkTaskResult{TaskID: taskID, Code: appErr.Code, Error: appErr.Message}
+	}
+	return models.BulkTaskResult{TaskID: taskID, Code: 500, Error: err.Error()}
+}
+
+// newBulkTaskResponse construye la respuesta contando aciertos y fallos
+func newBulkTaskResponse(results []models.BulkTaskResult) *models.BulkTaskResponse {
+	resp := &models.BulkTaskResponse{Results: results}
+	for _, result := range results {
+		if result.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp
+}
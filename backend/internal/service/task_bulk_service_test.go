@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// bulkStore tareas y eventos en memoria que imitan la transacción de WithinTx: lo
+// escrito dentro es visible para las lecturas siguientes y se descarta si fn falla
+type bulkStore struct {
+	tasks    map[string]models.Task
+	blockers map[string][]string // tarea bloqueada -> bloqueadoras
+	changes  []models.TaskChange
+}
+
+func newBulkStore(statuses map[string]string) *bulkStore {
+	st := &bulkStore{tasks: map[string]models.Task{}, blockers: map[string][]string{}}
+	for id, status := range statuses {
+		st.tasks[id] = models.Task{ID: id, WorkspaceID: policyWorkspace, Title: id, Status: status, CreatedBy: "creator", Version: 1}
+	}
+	return st
+}
+
+// service arma BulkTaskService sobre el store
+func (st *bulkStore) service() *BulkTaskService {
+	taskRepo := &MockTaskRepository{
+		GetByIDFunc: func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+			task, ok := st.tasks[id]
+			if !ok || task.WorkspaceID != workspaceID {
+				return nil, fmt.Errorf("tarea no encontrada")
+			}
+			return &task, nil
+		},
+		UpdateStatusFunc: func(ctx context.Context, workspaceID, id, fromStatus, status string, version int, actorID string) error {
+			task := st.tasks[id]
+			if task.Status != fromStatus {
+				return errors.ErrTaskStatusConflict
+			}
+			task.Status = status
+			task.Version++
+			st.tasks[id] = task
+			return nil
+		},
+	}
+	dependencyRepo := &MockDependencyRepository{
+		CountOpenBlockersFunc: func(ctx context.Context, workspaceID, taskID string) (int, error) {
+			open := 0
+			for _, blockerID := range st.blockers[taskID] {
+				if status := st.tasks[blockerID].Status; status != models.TaskStatusCompleted && status != models.TaskStatusCancelled {
+					open++
+				}
+			}
+			return open, nil
+		},
+	}
+	changeRepo := &MockTaskChangeRepository{
+		CreateFunc: func(ctx context.Context, change *models.TaskChange) error {
+			recorded := *change
+			task := *change.Task
+			recorded.Task = &task
+			st.changes = append(st.changes, recorded)
+			return nil
+		},
+	}
+	transactor := &MockTransactor{
+		WithinTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			tasks := make(map[string]models.Task, len(st.tasks))
+			for id, task := range st.tasks {
+				tasks[id] = task
+			}
+			changes := len(st.changes)
+
+			if err := fn(ctx); err != nil {
+				st.tasks = tasks
+				st.changes = st.changes[:changes]
+				return err
+			}
+			return nil
+		},
+	}
+
+	taskService := NewTaskService(taskRepo, &MockProjectRepository{}, &MockWorkspaceRepository{}, dependencyRepo, &MockUserRepository{}, transactor, NewEventHub(changeRepo, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour))
+	return NewBulkTaskService(taskService, NewLabelService(&MockLabelRepository{}, taskRepo), transactor, 100)
+}
+
+func TestBulkApplyAtomicSeesEarlierChanges(t *testing.T) {
+	st := newBulkStore(map[string]string{
+		"task-a": models.TaskStatusInProgress,
+		"task-b": models.TaskStatusInProgress,
+	})
+	// task-b solo puede completarse si task-a se completó antes en el mismo lote
+	st.blockers["task-b"] = []string{"task-a"}
+
+	resp, err := st.service().Apply(context.Background(), &models.BulkTaskRequest{
+		TaskIDs:   []string{"task-b", "task-a"},
+		Operation: models.BulkOpUpdateStatus,
+		Status:    models.TaskStatusCompleted,
+		Atomic:    true,
+	}, policyActors["creator"])
+	if err != nil {
+		t.Fatalf("Apply: error inesperado %v", err)
+	}
+
+	if resp.RolledBack || resp.Succeeded != 2 || resp.Failed != 0 {
+		t.Fatalf("respuesta = %+v, se esperaban 2 tareas aplicadas", resp)
+	}
+	for i, taskID := range []string{"task-b", "task-a"} {
+		if result := resp.Results[i]; result.TaskID != taskID || !result.Success || result.Code != 200 {
+			t.Errorf("resultado %d = %+v, se esperaba %s aplicada", i, result, taskID)
+		}
+	}
+
+	for _, taskID := range []string{"task-a", "task-b"} {
+		if task := st.tasks[taskID]; task.Status != models.TaskStatusCompleted || task.Version != 2 {
+			t.Errorf("%s quedó %s versión %d, se esperaba completed versión 2", taskID, task.Status, task.Version)
+		}
+	}
+
+	if len(st.changes) != 2 {
+		t.Fatalf("eventos publicados = %d, se esperaban 2", len(st.changes))
+	}
+	for _, change := range st.changes {
+		if change.Type != models.TaskChangeStatusChanged || change.Task.Status != models.TaskStatusCompleted || change.Task.Version != 2 {
+			t.Errorf("evento de %s = %s con estado %s versión %d, se esperaba el estado tras el cambio", change.TaskID, change.Type, change.Task.Status, change.Task.Version)
+		}
+	}
+}
+
+func TestBulkApplyAtomicRollsBackWholeBatch(t *testing.T) {
+	st := newBulkStore(map[string]string{
+		"task-a": models.TaskStatusInProgress,
+		"task-b": models.TaskStatusInProgress,
+		"task-x": models.TaskStatusInProgress,
+	})
+	// task-c se procesa después de task-a y task-b y está bloqueada por task-x, que no forma parte del lote
+	st.tasks["task-c"] = models.Task{ID: "task-c", WorkspaceID: policyWorkspace, Status: models.TaskStatusInProgress, CreatedBy: "creator", Version: 1}
+	st.blockers["task-c"] = []string{"task-x"}
+
+	resp, err := st.service().Apply(context.Background(), &models.BulkTaskRequest{
+		TaskIDs:   []string{"task-a", "task-c", "task-b"},
+		Operation: models.BulkOpUpdateStatus,
+		Status:    models.TaskStatusCompleted,
+		Atomic:    true,
+	}, policyActors["creator"])
+	if err != nil {
+		t.Fatalf("Apply: error inesperado %v", err)
+	}
+
+	if !resp.RolledBack || resp.Succeeded != 0 || resp.Failed != 3 {
+		t.Fatalf("respuesta = %+v, se esperaba el lote revertido", resp)
+	}
+	for _, result := range resp.Results {
+		if result.Success || result.Code != 409 {
+			t.Errorf("resultado de %s = %+v, se esperaba 409", result.TaskID, result)
+		}
+	}
+	if failed := resp.Results[1]; failed.TaskID != "task-c" || failed.Error == "" {
+		t.Errorf("resultado de task-c = %+v, se esperaba el motivo del fallo", failed)
+	}
+
+	for _, taskID := range []string{"task-a", "task-b", "task-c"} {
+		if task := st.tasks[taskID]; task.Status != models.TaskStatusInProgress || task.Version != 1 {
+			t.Errorf("%s quedó %s versión %d, se esperaba sin cambios", taskID, task.Status, task.Version)
+		}
+	}
+	if len(st.changes) != 0 {
+		t.Errorf("eventos publicados = %d, se esperaba que se revirtieran con el lote", len(st.changes))
+	}
+}
+
+func TestBulkApplyNonAtomicKeepsSuccessfulTasks(t *testing.T) {
+	st := newBulkStore(map[string]string{
+		"task-a": models.TaskStatusInProgress,
+		"task-x": models.TaskStatusInProgress,
+	})
+	st.tasks["task-c"] = models.Task{ID: "task-c", WorkspaceID: policyWorkspace, Status: models.TaskStatusInProgress, CreatedBy: "creator", Version: 1}
+	st.blockers["task-c"] = []string{"task-x"}
+
+	resp, err := st.service().Apply(context.Background(), &models.BulkTaskRequest{
+		TaskIDs:   []string{"task-a", "task-c"},
+		Operation: models.BulkOpUpdateStatus,
+		Status:    models.TaskStatusCompleted,
+	}, policyActors["creator"])
+	if err != nil {
+		t.Fatalf("Apply: error inesperado %v", err)
+	}
+
+	if resp.RolledBack || resp.Succeeded != 1 || resp.Failed != 1 {
+		t.Fatalf("respuesta = %+v, se esperaba 1 aplicada y 1 fallida", resp)
+	}
+	if st.tasks["task-a"].Status != models.TaskStatusCompleted || st.tasks["task-c"].Status != models.TaskStatusInProgress {
+		t.Errorf("estados = %s y %s, se esperaba task-a completada y task-c sin cambios", st.tasks["task-a"].Status, st.tasks["task-c"].Status)
+	}
+	if len(st.changes) != 1 || st.changes[0].TaskID != "task-a" {
+		t.Errorf("eventos publicados = %+v, se esperaba solo el de task-a", st.changes)
+	}
+}
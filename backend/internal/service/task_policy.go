@@ -0,0 +1,118 @@
+package service
+
+import (
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// TaskAction representa una operación sobre una tarea sujeta a autorización
+type TaskAction string
+
+// Acciones soportadas por la política de tareas
+const (
+	TaskActionView         TaskAction = "view"
+	TaskActionUpdate       TaskAction = "update"
+	TaskActionUpdateStatus TaskAction = "update_status"
+	TaskActionAssign       TaskAction = "assign"
+	TaskActionDelete       TaskAction = "delete"
+)
+
+// CanPerformTaskAction indica si el actor puede realizar la acción sobre la tarea.
+// Nadie accede a tareas de otro workspace. Los administradores (del sistema o del
+// workspace) pueden hacerlo todo; el creador también. Cualquiera de los responsables
+// puede ver, editar y cambiar el estado. Los seguidores y los viewers solo pueden ver
+func CanPerformTaskAction(task *models.Task, actor *models.Actor, action TaskAction) bool {
+	if task.WorkspaceID != actor.WorkspaceID {
+		return false
+	}
+
+	if actor.IsAdmin() || actor.IsWorkspaceAdmin() {
+		return true
+	}
+
+	isCreator := task.CreatedBy == actor.UserID
+	isAssignee := (task.AssignedTo != nil && *task.AssignedTo == actor.UserID) || containsUser(task.Assignees, actor.UserID)
+	isWatcher := containsUser(task.Watchers, actor.UserID)
+
+	if actor.Role == models.RoleViewer && action != TaskActionView {
+		return false
+	}
+
+	switch action {
+	case TaskActionView:
+		return isCreator || isAssignee || isWatcher
+	case TaskActionUpdate, TaskActionUpdateStatus:
+		return isCreator || isAssignee
+	case TaskActionAssign, TaskActionDelete:
+		return isCreator
+	default:
+		return false
+	}
+}
+
+// TaskChangeFor retorna el evento tal como lo recibe el actor, o false si no debe
+// recibirlo. Quien puede ver la tarea tras el cambio recibe el evento completo; quien
+// la veía antes y ya no, un TaskChangeRevoked sin el estado de la tarea
+func TaskChangeFor(change *models.TaskChange, actor *models.Actor) (models.TaskChange, bool) {
+	if change.Task == nil || change.WorkspaceID != actor.WorkspaceID {
+		return models.TaskChange{}, false
+	}
+
+	if CanPerformTaskAction(change.Task, actor, TaskActionView) {
+		return *change, true
+	}
+
+	if containsUser(change.PreviousViewers, actor.UserID) {
+		return models.TaskChange{
+			ID:          change.ID,
+			Type:        models.TaskChangeRevoked,
+			WorkspaceID: change.WorkspaceID,
+			TaskID:      change.TaskID,
+			CreatedAt:   change.CreatedAt,
+		}, true
+	}
+
+	return models.TaskChange{}, false
+}
+
+// taskViewers usuarios que ven la tarea sin ser administradores: creador,
+// responsables y seguidores
+func taskViewers(task *models.Task) []string {
+	viewers := []string{task.CreatedBy}
+	if task.AssignedTo != nil && !containsUser(viewers, *task.AssignedTo) {
+		viewers = append(viewers, *task.AssignedTo)
+	}
+	for _, userID := range append(append([]string{}, task.Assignees...), task.Watchers...) {
+		if !containsUser(viewers, userID) {
+			viewers = append(viewers, userID)
+		}
+	}
+	return viewers
+}
+
+// containsUser indica si el usuario está en la lista de IDs
+func containsUser(userIDs []string, userID string) bool {
+	for _, id := range userIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeWorkspaceListing retorna ErrForbidden si el actor no administra el sistema
+// ni el workspace activo, los únicos que pueden listar todas sus tareas
+func authorizeWorkspaceListing(actor *models.Actor) error {
+	if !actor.IsAdmin() && !actor.IsWorkspaceAdmin() {
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeTask retorna ErrForbidden si el actor no puede realizar la acción
+func authorizeTask(task *models.Task, actor *models.Actor, action TaskAction) error {
+	if !CanPerformTaskAction(task, actor, action) {
+		return errors.ErrForbidden
+	}
+	return nil
+}
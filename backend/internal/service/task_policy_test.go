@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+const (
+	policyWorkspace      = "ws-1"
+	otherPolicyWorkspace = "ws-2"
+)
+
+// policyTask tarea de ws-1 creada por creator y asignada a assignee
+func policyTask(workspaceID string) *models.Task {
+	assignee := "assignee"
+	return &models.Task{
+		ID:          "task-1",
+		WorkspaceID: workspaceID,
+		Title:       "Tarea",
+		Status:      models.TaskStatusPending,
+		Priority:    "medium",
+		CreatedBy:   "creator",
+		AssignedTo:  &assignee,
+		Assignees:   []string{assignee},
+	}
+}
+
+// policyActors actores de ws-1 usados en las tablas de la política
+var policyActors = map[string]*models.Actor{
+	"creator":      {UserID: "creator", Role: models.RoleMember, WorkspaceID: policyWorkspace, WorkspaceRole: models.WorkspaceRoleMember},
+	"assignee":     {UserID: "assignee", Role: models.RoleMember, WorkspaceID: policyWorkspace, WorkspaceRole: models.WorkspaceRoleMember},
+	"non-member":   {UserID: "stranger", Role: models.RoleMember, WorkspaceID: policyWorkspace, WorkspaceRole: models.WorkspaceRoleMember},
+	"global admin": {UserID: "admin", Role: models.RoleAdmin, WorkspaceID: policyWorkspace, WorkspaceRole: models.WorkspaceRoleMember},
+}
+
+// policyCases resultado esperado por actor y acción sobre una tarea de ws-1. Una tarea
+// de otro workspace se rechaza siempre
+var policyCases = []struct {
+	actor   string
+	allowed map[TaskAction]bool
+}{
+	{actor: "creator", allowed: map[TaskAction]bool{
+		TaskActionView: true, TaskActionUpdate: true, TaskActionUpdateStatus: true, TaskActionAssign: true, TaskActionDelete: true,
+	}},
+	{actor: "assignee", allowed: map[TaskAction]bool{
+		TaskActionView: true, TaskActionUpdate: true, TaskActionUpdateStatus: true,
+	}},
+	{actor: "non-member", allowed: map[TaskAction]bool{}},
+	{actor: "global admin", allowed: map[TaskAction]bool{
+		TaskActionView: true, TaskActionUpdate: true, TaskActionUpdateStatus: true, TaskActionAssign: true, TaskActionDelete: true,
+	}},
+}
+
+var policyActions = []TaskAction{TaskActionView, TaskActionUpdate, TaskActionUpdateStatus, TaskActionAssign, TaskActionDelete}
+
+func TestCanPerformTaskAction(t *testing.T) {
+	for _, tc := range policyCases {
+		for _, action := range policyActions {
+			actor := policyActors[tc.actor]
+
+			t.Run(tc.actor+"/"+string(action), func(t *testing.T) {
+				if got := CanPerformTaskAction(policyTask(policyWorkspace), actor, action); got != tc.allowed[action] {
+					t.Errorf("CanPerformTaskAction = %v, se esperaba %v", got, tc.allowed[action])
+				}
+			})
+
+			t.Run(tc.actor+"/otro workspace/"+string(action), func(t *testing.T) {
+				if CanPerformTaskAction(policyTask(otherPolicyWorkspace), actor, action) {
+					t.Error("se permitió una acción sobre una tarea de otro workspace")
+				}
+			})
+		}
+	}
+}
+
+// TestTaskServiceAuthorization verifica que cada operación del servicio aplique la
+// política antes de escribir
+func TestTaskServiceAuthorization(t *testing.T) {
+	operations := map[TaskAction]func(s *TaskService, actor *models.Actor) error{
+		TaskActionView: func(s *TaskService, actor *models.Actor) error {
+			_, err := s.GetTaskByID(context.Background(), "task-1", actor)
+			return err
+		},
+		TaskActionUpdate: func(s *TaskService, actor *models.Actor) error {
+			_, err := s.UpdateTask(context.Background(), "task-1", &models.UpdateTaskRequest{}, nil, actor)
+			return err
+		},
+		TaskActionUpdateStatus: func(s *TaskService, actor *models.Actor) error {
+			_, _, err := s.UpdateTaskStatus(context.Background(), "task-1", &models.UpdateTaskStatusRequest{Status: models.TaskStatusInProgress}, nil, actor)
+			return err
+		},
+		TaskActionAssign: func(s *TaskService, actor *models.Actor) error {
+			_, err := s.AssignTask(context.Background(), "task-1", &models.AssignTaskRequest{}, actor)
+			return err
+		},
+		TaskActionDelete: func(s *TaskService, actor *models.Actor) error {
+			return s.DeleteTask(context.Background(), "task-1", nil, actor)
+		},
+	}
+
+	for _, taskWorkspace := range []string{policyWorkspace, otherPolicyWorkspace} {
+		for _, tc := range policyCases {
+			for _, action := range policyActions {
+				name := tc.actor + "/" + string(action)
+				if taskWorkspace != policyWorkspace {
+					name = tc.actor + "/otro workspace/" + string(action)
+				}
+
+				t.Run(name, func(t *testing.T) {
+					written := false
+					write := func() { written = true }
+					taskRepo := &MockTaskRepository{
+						GetByIDFunc: func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+							if workspaceID != taskWorkspace {
+								return nil, errors.ErrTaskNotFound
+							}
+							return policyTask(taskWorkspace), nil
+						},
+						UpdateFunc: func(ctx context.Context, workspaceID, id, title, description, priority string, dueDate interface{}, version int, actorID string) error {
+							write()
+							return nil
+						},
+						UpdateStatusFunc: func(ctx context.Context, workspaceID, id, fromStatus, status string, version int, actorID string) error {
+							write()
+							return nil
+						},
+						AssignTaskFunc: func(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+							write()
+							return nil
+						},
+						DeleteFunc: func(ctx context.Context, workspaceID, id string, version int, actorID string) error {
+							write()
+							return nil
+						},
+					}
+					svc := NewTaskService(taskRepo, &MockProjectRepository{}, &MockWorkspaceRepository{}, &MockDependencyRepository{}, &MockUserRepository{}, &MockTransactor{},
+						NewEventHub(&MockTaskChangeRepository{}, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour))
+
+					err := operations[action](svc, policyActors[tc.actor])
+
+					switch {
+					case taskWorkspace != policyWorkspace:
+						if err != errors.ErrTaskNotFound {
+							t.Errorf("error = %v, se esperaba ErrTaskNotFound", err)
+						}
+					case tc.allowed[action]:
+						if err != nil {
+							t.Errorf("error inesperado: %v", err)
+						}
+					default:
+						if err != errors.ErrForbidden {
+							t.Errorf("error = %v, se esperaba ErrForbidden", err)
+						}
+					}
+
+					if written && (taskWorkspace != policyWorkspace || !tc.allowed[action]) {
+						t.Error("se escribió la tarea sin autorización")
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestAssignToMeRequiresAssign verifica que asignarse una tarea requiera poder
+// asignarla: verla (por ejemplo como seguidor) no alcanza
+func TestAssignToMeRequiresAssign(t *testing.T) {
+	for _, tc := range policyCases {
+		t.Run(tc.actor, func(t *testing.T) {
+			written := false
+			taskRepo := &MockTaskRepository{
+				GetByIDFunc: func(ctx context.Context, workspaceID, id, viewerID string) (*models.Task, error) {
+					return policyTask(workspaceID), nil
+				},
+				AddAssigneeFunc: func(ctx context.Context, workspaceID, taskID, userID, actorID string) error {
+					written = true
+					return nil
+				},
+			}
+			userRepo := &MockUserRepository{
+				GetByIDFunc: func(ctx context.Context, id string) (*models.User, error) {
+					return &models.User{ID: id, Role: models.RoleMember}, nil
+				},
+			}
+			workspaceRepo := &MockWorkspaceRepository{
+				GetMemberFunc: func(ctx context.Context, workspaceID, userID string) (*models.WorkspaceMember, error) {
+					return &models.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: models.WorkspaceRoleMember}, nil
+				},
+			}
+			svc := NewTaskService(taskRepo, &MockProjectRepository{}, workspaceRepo, &MockDependencyRepository{}, userRepo, &MockTransactor{},
+				NewEventHub(&MockTaskChangeRepository{}, &MockWorkspaceRepository{}, &MockUserRepository{}, time.Hour))
+
+			_, err := svc.AssignToMe(context.Background(), "task-1", policyActors[tc.actor])
+
+			if tc.allowed[TaskActionAssign] {
+				if err != nil {
+					t.Errorf("error inesperado: %v", err)
+				}
+				if !written {
+					t.Error("no se agregó al actor como responsable")
+				}
+				return
+			}
+			if err != errors.ErrForbidden {
+				t.Errorf("error = %v, se esperaba ErrForbidden", err)
+			}
+			if written {
+				t.Error("se asignó la tarea sin autorización")
+			}
+		})
+	}
+}
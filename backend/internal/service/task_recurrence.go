@@ -0,0 +1,103 @@
+package service
+
+import (
+	"time"
+
+	"github.com/taskflow/backend/internal/models"
+)
+
+// nextOccurrence calcula el vencimiento de la ocurrencia que sigue a LastDueAt.
+// Retorna nil si la serie terminó por fecha de fin o por cantidad de ocurrencias
+func nextOccurrence(rule *models.TaskRecurrence) *time.Time {
+	if rule.MaxOccurrences != nil && rule.Occurrences >= *rule.MaxOccurrences {
+		return nil
+	}
+
+	interval := rule.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var next time.Time
+	switch rule.Frequency {
+	case models.RecurrenceDaily:
+		next = rule.LastDueAt.AddDate(0, 0, interval)
+	case models.RecurrenceWeekly:
+		next = nextWeekly(rule, interval)
+	case models.RecurrenceMonthly:
+		next = nextMonthly(rule, interval)
+	default:
+		return nil
+	}
+
+	if rule.EndsAt != nil && next.After(*rule.EndsAt) {
+		return nil
+	}
+
+	return &next
+}
+
+// nextWeekly busca el siguiente día permitido después de LastDueAt en una semana
+// que corresponda al intervalo, contando semanas (de lunes a domingo) desde StartsAt.
+// Sin días configurados se repite el día de la semana de StartsAt
+func nextWeekly(rule *models.TaskRecurrence, interval int) time.Time {
+	allowed := make(map[time.Weekday]bool, 7)
+	for _, day := range rule.Weekdays {
+		allowed[time.Weekday(day)] = true
+	}
+	if len(allowed) == 0 {
+		allowed[rule.StartsAt.Weekday()] = true
+	}
+
+	anchor := startOfWeek(rule.StartsAt)
+	last := atTimeOfDay(rule.LastDueAt, rule.StartsAt)
+
+	// En 7 * (intervalo + 1) días siempre hay un día permitido en una semana válida
+	for offset := 1; offset <= 7*(interval+1); offset++ {
+		candidate := last.AddDate(0, 0, offset)
+		if !allowed[candidate.Weekday()] {
+			continue
+		}
+
+		weeks := daysBetween(anchor, startOfWeek(candidate)) / 7
+		if weeks%interval == 0 {
+			return candidate
+		}
+	}
+
+	return last.AddDate(0, 0, 7*interval)
+}
+
+// nextMonthly avanza el intervalo de meses desde LastDueAt en el día del mes de la
+// regla (o el de StartsAt). Si el mes es más corto se usa su último día
+func nextMonthly(rule *models.TaskRecurrence, interval int) time.Time {
+	day := rule.StartsAt.Day()
+	if rule.MonthDay != nil {
+		day = *rule.MonthDay
+	}
+
+	month := time.Date(rule.LastDueAt.Year(), rule.LastDueAt.Month(), 1, 0, 0, 0, 0, rule.LastDueAt.Location()).AddDate(0, interval, 0)
+	if lastDay := month.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+
+	return atTimeOfDay(month.AddDate(0, 0, day-1), rule.StartsAt)
+}
+
+// startOfWeek retorna el lunes de la semana de t a medianoche
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween cuenta los días de calendario entre dos fechas
+func daysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// atTimeOfDay retorna la fecha de date con la hora de clock
+func atTimeOfDay(date, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, date.Location())
+}
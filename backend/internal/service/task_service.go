@@ -2,96 +2,254 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/taskflow/backend/internal/domain"
 	"github.com/taskflow/backend/internal/errors"
 	"github.com/taskflow/backend/internal/models"
+	"github.com/taskflow/backend/internal/utils/validation"
 )
 
 // TaskService maneja la lógica de negocio de tareas
 type TaskService struct {
-	taskRepo domain.TaskRepository
+	taskRepo       domain.TaskRepository
+	projectRepo    domain.ProjectRepository
+	workspaceRepo  domain.WorkspaceRepository
+	dependencyRepo domain.DependencyRepository
+	userRepo       domain.UserRepository
+	transactor     domain.Transactor
+	events         *EventHub
 }
 
 // NewTaskService crea una nueva instancia de TaskService
-func NewTaskService(taskRepo domain.TaskRepository) *TaskService {
+func NewTaskService(taskRepo domain.TaskRepository, projectRepo domain.ProjectRepository, workspaceRepo domain.WorkspaceRepository, dependencyRepo domain.DependencyRepository, userRepo domain.UserRepository, transactor domain.Transactor, events *EventHub) *TaskService {
 	return &TaskService{
-		taskRepo: taskRepo,
+		taskRepo:       taskRepo,
+		projectRepo:    projectRepo,
+		workspaceRepo:  workspaceRepo,
+		dependencyRepo: dependencyRepo,
+		userRepo:       userRepo,
+		transactor:     transactor,
+		events:         events,
 	}
 }
 
 // CreateTask crea una nueva tarea
-func (s *TaskService) CreateTask(ctx context.Context, req *models.CreateTaskRequest, userID string) (*models.Task, error) {
+func (s *TaskService) CreateTask(ctx context.Context, req *models.CreateTaskRequest, actor *models.Actor) (*models.Task, error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			log.Printf("🔴 PANIC en CreateTask: %v\n", rec)
 		}
 	}()
 
-	log.Printf("📝 CreateTask Service - Input: title=%s, userID=%s\n", req.Title, userID)
+	log.Printf("📝 CreateTask Service - Input: title=%s, userID=%s, workspaceID=%s\n", req.Title, actor.UserID, actor.WorkspaceID)
 
 	// Convertir string de fecha a *time.Time
 	var dueDate *time.Time
 	if req.DueDate != nil && *req.DueDate != "" {
-		formats := []string{
-			time.RFC3339,          // "2006-01-02T15:04:05Z07:00"
-			"2006-01-02T15:04:05", // Sin timezone
-			"2006-01-02 15:04:05", // Con espacio
-			"2006-01-02",          // Solo fecha
-		}
-
-		var parseErr error
-		for _, format := range formats {
-			if t, err := time.Parse(format, *req.DueDate); err == nil {
-				dueDate = &t
-				log.Printf("📅 Due date parsed: %v (format: %s)\n", dueDate, format)
-				break
-			} else {
-				parseErr = err
-			}
+		t, err := validation.ParseDueDate(*req.DueDate)
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
 		}
+		dueDate = &t
+		log.Printf("📅 Due date parsed: %v\n", dueDate)
+	}
 
-		if dueDate == nil && parseErr != nil {
-			return nil, errors.NewBadRequest(fmt.Sprintf("formato de fecha inválido: %s", *req.DueDate))
+	// Validar que el proyecto exista en el workspace y no esté archivado
+	if req.ProjectID != nil && *req.ProjectID != "" {
+		project, err := s.projectRepo.GetByID(ctx, actor.WorkspaceID, *req.ProjectID)
+		if err != nil {
+			return nil, errors.ErrProjectNotFound
 		}
+		if project.Archived {
+			return nil, errors.ErrProjectArchived
+		}
+	} else {
+		req.ProjectID = nil
 	}
 
-	taskID, err := s.taskRepo.Create(ctx, req.Title, req.Description, req.Priority, dueDate, userID)
-	if err != nil {
-		log.Printf("🔴 ERROR en CreateTask Service - Repository Error: %v (type: %T)\n", err, err)
-		return nil, errors.NewInternalServerError(fmt.Sprintf("error al crear tarea: %v", err))
+	// Validar que la tarea padre exista y sea visible para el actor
+	if req.ParentID != nil && *req.ParentID != "" {
+		if _, err := s.getParentTask(ctx, *req.ParentID, actor); err != nil {
+			return nil, err
+		}
+	} else {
+		req.ParentID = nil
 	}
 
-	log.Printf("✅ Task created in repository: %s\n", taskID)
+	// La tarea y su evento se confirman juntos
+	var task *models.Task
+	err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		taskID, err := s.taskRepo.Create(ctx, actor.WorkspaceID, req.Title, req.Description, req.Priority, dueDate, req.ProjectID, req.ParentID, actor.UserID)
+		if err != nil {
+			log.Printf("🔴 ERROR en CreateTask Service - Repository Error: %v (type: %T)\n", err, err)
+			return errors.NewInternalServerError(fmt.Sprintf("error al crear tarea: %v", err))
+		}
+
+		log.Printf("✅ Task created in repository: %s\n", taskID)
 
-	// Obtener la tarea creada
-	task, err := s.taskRepo.GetByID(ctx, taskID)
+		// Obtener la tarea creada
+		task, err = s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+		if err != nil {
+			log.Printf("🔴 ERROR en CreateTask Service - GetByID Error: %v (type: %T)\n", err, err)
+			return errors.NewInternalServerError(fmt.Sprintf("error al obtener tarea: %v", err))
+		}
+
+		if err := s.events.Publish(ctx, models.TaskChangeCreated, task, nil, actor); err != nil {
+			return errors.NewInternalServerError(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		log.Printf("🔴 ERROR en CreateTask Service - GetByID Error: %v (type: %T)\n", err, err)
-		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener tarea: %v", err))
+		return nil, err
 	}
 
 	log.Printf("✅ Task retrieved successfully: %+v\n", task)
 	return task, nil
 }
 
-// GetTasks obtiene tareas del usuario con paginación
-func (s *TaskService) GetTasks(ctx context.Context, userID string, status string, page, pageSize int) (*models.TasksListResponse, error) {
-	if page < 1 {
-		page = 1
+// GetTasks obtiene tareas del workspace que cumplen el filtro, ordenadas y con paginación
+func (s *TaskService) GetTasks(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, page, pageSize int) (*models.TasksListResponse, error) {
+	page, pageSize = normalizePagination(page, pageSize)
+
+	tasks, total, err := s.taskRepo.GetAll(ctx, workspaceID, filter, sort, page, pageSize)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener tareas: %v", err))
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+
+	return newTasksListResponse(tasks, total, page, pageSize), nil
+}
+
+// GetWorkspaceTasks obtiene todas las tareas del workspace activo que cumplen el filtro.
+// Solo para administradores del sistema o del workspace
+func (s *TaskService) GetWorkspaceTasks(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, page, pageSize int, actor *models.Actor) (*models.TasksListResponse, error) {
+	if err := authorizeWorkspaceListing(actor); err != nil {
+		return nil, err
+	}
+	return s.GetTasks(ctx, actor.WorkspaceID, filter, sort, page, pageSize)
+}
+
+// GetWorkspaceTasksByCursor es GetWorkspaceTasks con paginación por cursor
+func (s *TaskService) GetWorkspaceTasksByCursor(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int, includeTotal bool, actor *models.Actor) (*models.TasksListResponse, error) {
+	if err := authorizeWorkspaceListing(actor); err != nil {
+		return nil, err
 	}
+	return s.GetTasksByCursor(ctx, actor.WorkspaceID, filter, sort, cursor, limit, includeTotal)
+}
 
-	tasks, total, err := s.taskRepo.GetAll(ctx, userID, status, page, pageSize)
+// GetTasksByCursor obtiene tareas del workspace con paginación por cursor. cursor vacío
+// empieza desde el inicio; includeTotal agrega el conteo total (consulta adicional).
+// No admite búsqueda: el cursor no incluye la relevancia y se perdería ese orden
+func (s *TaskService) GetTasksByCursor(ctx context.Context, workspaceID string, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int, includeTotal bool) (*models.TasksListResponse, error) {
+	if filter.Query != "" {
+		return nil, errors.NewBadRequest("La paginación por cursor no admite búsqueda (q); use page")
+	}
+
+	_, limit = normalizePagination(1, limit)
+
+	if sort.Field == "" {
+		sort = models.TaskSort{Field: models.TaskSortCreatedAt, Descending: true}
+	}
+
+	var after *models.TaskCursor
+	if cursor != "" {
+		decoded, err := decodeTaskCursor(cursor)
+		if err != nil || decoded.Sort != sort.Field || decoded.Descending != sort.Descending {
+			return nil, errors.NewBadRequest("Cursor inválido o no corresponde al orden solicitado")
+		}
+		after = decoded
+	}
+
+	tasks, next, err := s.taskRepo.GetAfter(ctx, workspaceID, filter, sort, after, limit)
 	if err != nil {
 		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener tareas: %v", err))
 	}
 
+	resp := &models.TasksListResponse{
+		Tasks:    tasks,
+		PageSize: limit,
+	}
+
+	if next != nil {
+		resp.NextCursor, err = encodeTaskCursor(next)
+		if err != nil {
+			return nil, errors.NewInternalServerError(fmt.Sprintf("error al generar cursor: %v", err))
+		}
+	}
+
+	if includeTotal {
+		resp.Total, err = s.taskRepo.Count(ctx, workspaceID, filter)
+		if err != nil {
+			return nil, errors.NewInternalServerError(fmt.Sprintf("error al contar tareas: %v", err))
+		}
+	}
+
+	return resp, nil
+}
+
+// encodeTaskCursor serializa el cursor como un token opaco para el cliente
+func encodeTaskCursor(cursor *models.TaskCursor) (string, error) {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeTaskCursor interpreta un token de cursor y valida sus valores antes de
+// que lleguen a la consulta
+func decodeTaskCursor(token string) (*models.TaskCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor models.TaskCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateUUID(cursor.ID); err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateTaskSort(cursor.Sort); err != nil {
+		return nil, err
+	}
+
+	if cursor.Value != nil {
+		switch cursor.Sort {
+		case models.TaskSortCreatedAt, models.TaskSortUpdatedAt, models.TaskSortDueDate:
+			if _, err := time.Parse("2006-01-02 15:04:05.999999", *cursor.Value); err != nil {
+				return nil, err
+			}
+		case models.TaskSortPriority:
+			if _, err := strconv.Atoi(*cursor.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &cursor, nil
+}
+
+// normalizePagination aplica los valores por defecto y límites de paginación
+func normalizePagination(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return page, pageSize
+}
+
+// newTasksListResponse construye la respuesta paginada de tareas
+func newTasksListResponse(tasks []models.Task, total, page, pageSize int) *models.TasksListResponse {
 	totalPages := (total + pageSize - 1) / pageSize
 
 	return &models.TasksListResponse{
@@ -100,26 +258,41 @@ func (s *TaskService) GetTasks(ctx context.Context, userID string, status string
 		Page:       page,
 		PageSize:   pageSize,
 		TotalPages: totalPages,
-	}, nil
+	}
 }
 
 // GetTaskByID obtiene una tarea específica
-func (s *TaskService) GetTaskByID(ctx context.Context, taskID string) (*models.Task, error) {
-	task, err := s.taskRepo.GetByID(ctx, taskID)
+func (s *TaskService) GetTaskByID(ctx context.Context, taskID string, actor *models.Actor) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
 	if err != nil {
 		return nil, errors.ErrTaskNotFound
 	}
+
+	if err := authorizeTask(task, actor, TaskActionView); err != nil {
+		return nil, err
+	}
+
 	return task, nil
 }
 
-// UpdateTask actualiza una tarea
-func (s *TaskService) UpdateTask(ctx context.Context, taskID string, req *models.UpdateTaskRequest) (*models.Task, error) {
+// UpdateTask actualiza una tarea. Con versions (If-Match) la actualización falla
+// con ErrTaskVersionConflict si ninguna es la actual porque otro usuario la modificó antes
+func (s *TaskService) UpdateTask(ctx context.Context, taskID string, req *models.UpdateTaskRequest, versions []int, actor *models.Actor) (*models.Task, error) {
 	// Obtener la tarea actual
-	task, err := s.taskRepo.GetByID(ctx, taskID)
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
 	if err != nil {
 		return nil, errors.ErrTaskNotFound
 	}
 
+	if err := authorizeTask(task, actor, TaskActionUpdate); err != nil {
+		return nil, err
+	}
+
+	version, err := matchTaskVersion(task, versions)
+	if err != nil {
+		return nil, err
+	}
+
 	// Preparar valores para actualizar (usar valores actuales si no se proporcionan nuevos)
 	title := task.Title
 	if req.Title != nil {
@@ -138,100 +311,412 @@ func (s *TaskService) UpdateTask(ctx context.Context, taskID string, req *models
 
 	dueDate := task.DueDate
 	if req.DueDate != nil && *req.DueDate != "" {
-		formats := []string{
-			time.RFC3339,          // "2006-01-02T15:04:05Z07:00"
-			"2006-01-02T15:04:05", // Sin timezone
-			"2006-01-02 15:04:05", // Con espacio
-			"2006-01-02",          // Solo fecha
-		}
-
-		var parseErr error
-		for _, format := range formats {
-			if t, err := time.Parse(format, *req.DueDate); err == nil {
-				dueDate = &t
-				break
-			} else {
-				parseErr = err
-			}
+		t, err := validation.ParseDueDate(*req.DueDate)
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
 		}
+		dueDate = &t
+	}
 
-		if dueDate == nil && parseErr != nil {
-			return nil, errors.NewBadRequest(fmt.Sprintf("formato de fecha inválido: %s", *req.DueDate))
+	// Actualizar tarea
+	return s.applyChange(ctx, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		err := s.taskRepo.Update(ctx, actor.WorkspaceID, taskID, title, description, priority, dueDate, version, actor.UserID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al actualizar tarea: %v", err))
 		}
+		return nil
+	})
+}
+
+// PatchTask aplica una actualización parcial (JSON Merge Patch): solo cambian los
+// campos presentes y null limpia la descripción o la fecha límite. versions funciona
+// como en UpdateTask
+func (s *TaskService) PatchTask(ctx context.Context, taskID string, patch models.TaskPatch, versions []int, actor *models.Actor) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
 	}
 
-	// Actualizar tarea
-	err = s.taskRepo.Update(ctx, taskID, title, description, priority, dueDate)
+	if err := authorizeTask(task, actor, TaskActionUpdate); err != nil {
+		return nil, err
+	}
+
+	version, err := matchTaskVersion(task, versions)
 	if err != nil {
-		return nil, errors.NewInternalServerError(fmt.Sprintf("error al actualizar tarea: %v", err))
+		return nil, err
+	}
+
+	if len(patch) == 0 {
+		return task, nil
 	}
 
-	// Obtener tarea actualizada
-	return s.taskRepo.GetByID(ctx, taskID)
+	return s.applyChange(ctx, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.Patch(ctx, actor.WorkspaceID, taskID, patch, version, actor.UserID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al actualizar tarea: %v", err))
+		}
+		return nil
+	})
 }
 
-// DeleteTask elimina una tarea
-func (s *TaskService) DeleteTask(ctx context.Context, taskID string) error {
+// DeleteTask mueve una tarea a la papelera. versions funciona como en UpdateTask
+func (s *TaskService) DeleteTask(ctx context.Context, taskID string, versions []int, actor *models.Actor) error {
 	// Verificar que la tarea existe
-	_, err := s.taskRepo.GetByID(ctx, taskID)
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
 	if err != nil {
 		return errors.ErrTaskNotFound
 	}
 
-	err = s.taskRepo.Delete(ctx, taskID)
+	if err := authorizeTask(task, actor, TaskActionDelete); err != nil {
+		return err
+	}
+
+	version, err := matchTaskVersion(task, versions)
 	if err != nil {
-		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar tarea: %v", err))
+		return err
 	}
 
-	return nil
+	return s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.taskRepo.Delete(ctx, actor.WorkspaceID, taskID, version, actor.UserID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al eliminar tarea: %v", err))
+		}
+
+		// El evento lleva la tarea tal como quedó en la papelera
+		deleted := task
+		if trashed, err := s.taskRepo.GetTrashedByID(ctx, actor.WorkspaceID, taskID, actor.UserID); err == nil {
+			deleted = trashed
+		}
+		if err := s.events.Publish(ctx, models.TaskChangeDeleted, deleted, task, actor); err != nil {
+			return errors.NewInternalServerError(err.Error())
+		}
+		return nil
+	})
 }
 
-// UpdateTaskStatus actualiza el estado de una tarea
-func (s *TaskService) UpdateTaskStatus(ctx context.Context, taskID string, req *models.UpdateTaskStatusRequest) (*models.Task, error) {
-	// Verificar que la tarea existe
-	_, err := s.taskRepo.GetByID(ctx, taskID)
+// GetTrash lista las tareas en la papelera, de la eliminada más recientemente a la
+// más antigua. Los administradores ven todas; el resto solo las que crearon, que
+// son las que pueden restaurar
+func (s *TaskService) GetTrash(ctx context.Context, page, pageSize int, actor *models.Actor) (*models.TasksListResponse, error) {
+	filter := models.TaskFilter{Trashed: true, LabelOwner: actor.UserID}
+	if !actor.IsAdmin() && !actor.IsWorkspaceAdmin() {
+		filter.CreatedBy = actor.UserID
+	}
+
+	return s.GetTasks(ctx, actor.WorkspaceID, filter, models.TaskSort{Field: models.TaskSortDeletedAt, Descending: true}, page, pageSize)
+}
+
+// RestoreTask saca una tarea de la papelera (requiere poder eliminarla)
+func (s *TaskService) RestoreTask(ctx context.Context, taskID string, actor *models.Actor) (*models.Task, error) {
+	task, err := s.taskRepo.GetTrashedByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
 	if err != nil {
 		return nil, errors.ErrTaskNotFound
 	}
 
+	if err := authorizeTask(task, actor, TaskActionDelete); err != nil {
+		return nil, err
+	}
+
+	return s.applyChange(ctx, models.TaskChangeRestored, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.Restore(ctx, actor.WorkspaceID, taskID, actor.UserID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al restaurar tarea: %v", err))
+		}
+		return nil
+	})
+}
+
+// UpdateTaskStatus actualiza el estado de una tarea respetando las transiciones
+// permitidas en el workspace. Cambiar al mismo estado no tiene efecto. Una tarea con
+// bloqueadores abiertos no puede iniciarse ni completarse. Completar una tarea con
+// subtareas abiertas se rechaza salvo que el request lo permita; en ese caso retorna
+// una advertencia. versions funciona como en UpdateTask
+func (s *TaskService) UpdateTaskStatus(ctx context.Context, taskID string, req *models.UpdateTaskStatusRequest, versions []int, actor *models.Actor) (*models.Task, string, error) {
+	// Verificar que la tarea existe
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, "", errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionUpdateStatus); err != nil {
+		return nil, "", err
+	}
+
+	version, err := matchTaskVersion(task, versions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if task.Status == req.Status {
+		return task, "", nil
+	}
+
+	transitions, err := s.statusTransitions(ctx, actor.WorkspaceID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := checkStatusTransition(transitions, task.Status, req.Status); err != nil {
+		return nil, "", err
+	}
+
+	if req.Status == models.TaskStatusInProgress || req.Status == models.TaskStatusCompleted {
+		blockers, err := s.dependencyRepo.CountOpenBlockers(ctx, actor.WorkspaceID, taskID)
+		if err != nil {
+			return nil, "", errors.NewInternalServerError(fmt.Sprintf("error al verificar dependencias: %v", err))
+		}
+
+		if blockers > 0 {
+			return nil, "", errors.NewAppError(
+				409,
+				fmt.Sprintf("La tarea está bloqueada por %d tareas abiertas", blockers),
+				"complete o cancele las tareas bloqueadoras antes de cambiar a "+req.Status,
+			)
+		}
+	}
+
+	var warning string
+	if req.Status == models.TaskStatusCompleted {
+		open, err := s.taskRepo.CountOpenSubtasks(ctx, actor.WorkspaceID, taskID)
+		if err != nil {
+			return nil, "", errors.NewInternalServerError(fmt.Sprintf("error al contar subtareas: %v", err))
+		}
+
+		if open > 0 {
+			if !req.AllowOpenSubtasks {
+				return nil, "", errors.NewAppError(
+					409,
+					fmt.Sprintf("La tarea tiene %d subtareas abiertas", open),
+					"envíe allow_open_subtasks=true para completarla de todas formas",
+				)
+			}
+			warning = fmt.Sprintf("La tarea se completó con %d subtareas abiertas", open)
+		}
+	}
+
 	// Actualizar estado
-	err = s.taskRepo.UpdateStatus(ctx, taskID, req.Status)
+	// La transición se verificó desde task.Status: el repositorio la rechaza si el
+	// estado cambió antes de bloquear la fila
+	task, err = s.applyChange(ctx, models.TaskChangeStatusChanged, taskID, task, actor, func(ctx context.Context) error {
+		err := s.taskRepo.UpdateStatus(ctx, actor.WorkspaceID, taskID, task.Status, req.Status, version, actor.UserID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al actualizar estado: %v", err))
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, errors.NewInternalServerError(fmt.Sprintf("error al actualizar estado: %v", err))
+		return nil, "", err
 	}
 
-	// Obtener tarea actualizada
-	return s.taskRepo.GetByID(ctx, taskID)
+	return task, warning, nil
 }
 
-// AssignTask asigna una tarea a un usuario
-func (s *TaskService) AssignTask(ctx context.Context, taskID string, req *models.AssignTaskRequest) (*models.Task, error) {
-	// Verificar que la tarea existe
-	_, err := s.taskRepo.GetByID(ctx, taskID)
+// SetParentTask mueve una tarea bajo otra tarea o la convierte en tarea raíz.
+// Rechaza los cambios que crearían un ciclo en la jerarquía
+func (s *TaskService) SetParentTask(ctx context.Context, taskID string, req *models.SetParentTaskRequest, actor *models.Actor) (*models.Task, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
 	if err != nil {
 		return nil, errors.ErrTaskNotFound
 	}
 
-	// Asignar tarea
-	userID := ""
-	if req.AssignedTo != nil {
-		userID = *req.AssignedTo
+	if err := authorizeTask(task, actor, TaskActionUpdate); err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil {
+		if *req.ParentID == taskID {
+			return nil, errors.NewAppError(422, "Una tarea no puede ser su propia tarea padre", "")
+		}
+
+		if _, err := s.getParentTask(ctx, *req.ParentID, actor); err != nil {
+			return nil, err
+		}
+
+		cycle, err := s.taskRepo.HasAncestor(ctx, actor.WorkspaceID, *req.ParentID, taskID)
+		if err != nil {
+			return nil, errors.NewInternalServerError(fmt.Sprintf("error al verificar jerarquía: %v", err))
+		}
+		if cycle {
+			return nil, errors.ErrTaskParentCycle
+		}
+	}
+
+	return s.applyChange(ctx, models.TaskChangeUpdated, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.SetParent(ctx, actor.WorkspaceID, taskID, req.ParentID, actor.UserID); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return appErr
+			}
+			return errors.NewInternalServerError(fmt.Sprintf("error al mover tarea: %v", err))
+		}
+		return nil
+	})
+}
+
+// GetSubtasks lista las subtareas directas de una tarea con paginación. Los
+// administradores ven todas; el resto solo las que crearon o tienen asignadas
+func (s *TaskService) GetSubtasks(ctx context.Context, taskID string, page, pageSize int, actor *models.Actor) (*models.TasksListResponse, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionView); err != nil {
+		return nil, err
+	}
+
+	filter := models.TaskFilter{ParentID: taskID, LabelOwner: actor.UserID}
+	if !actor.IsAdmin() && !actor.IsWorkspaceAdmin() {
+		filter.UserID = actor.UserID
 	}
 
-	err = s.taskRepo.AssignTask(ctx, taskID, userID)
+	return s.GetTasks(ctx, actor.WorkspaceID, filter, models.TaskSort{Field: models.TaskSortCreatedAt}, page, pageSize)
+}
+
+// getParentTask obtiene una tarea que se usará como padre y verifica que el actor pueda verla
+func (s *TaskService) getParentTask(ctx context.Context, parentID string, actor *models.Actor) (*models.Task, error) {
+	parent, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, parentID, actor.UserID)
+	if err != nil {
+		return nil, errors.NewAppError(422, "La tarea padre no existe en este workspace", "")
+	}
+
+	if !CanPerformTaskAction(parent, actor, TaskActionView) {
+		return nil, errors.NewAppError(422, "La tarea padre no existe en este workspace", "")
+	}
+
+	return parent, nil
+}
+
+// statusTransitions obtiene las transiciones del workspace o las por defecto
+func (s *TaskService) statusTransitions(ctx context.Context, workspaceID string) (map[string][]string, error) {
+	transitions, err := s.workspaceRepo.GetStatusTransitions(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener transiciones de estado: %v", err))
+	}
+
+	if transitions == nil {
+		return DefaultStatusTransitions, nil
+	}
+	return transitions, nil
+}
+
+// applyChange ejecuta write y publica el evento con la tarea resultante en una misma
+// transacción, de modo que el evento se confirma junto con el cambio. before es la
+// tarea antes del cambio
+func (s *TaskService) applyChange(ctx context.Context, changeType, taskID string, before *models.Task, actor *models.Actor, write func(ctx context.Context) error) (*models.Task, error) {
+	return applyTaskChange(ctx, s.transactor, s.taskRepo, s.events, changeType, taskID, before, actor, write)
+}
+
+// applyTaskChange implementa applyChange para los servicios que modifican una tarea
+// a través de otro repositorio, como la checklist
+func applyTaskChange(ctx context.Context, transactor domain.Transactor, taskRepo domain.TaskRepository, events *EventHub, changeType, taskID string, before *models.Task, actor *models.Actor, write func(ctx context.Context) error) (*models.Task, error) {
+	var task *models.Task
+	err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		task, err = taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+		if err != nil {
+			return errors.NewInternalServerError(fmt.Sprintf("error al obtener tarea: %v", err))
+		}
+
+		if err := events.Publish(ctx, changeType, task, before, actor); err != nil {
+			return errors.NewInternalServerError(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// matchTaskVersion rechaza la escritura si el cliente envió versiones (If-Match) y
+// ninguna es la actual. Retorna la versión que el repositorio vuelve a verificar con
+// la fila bloqueada, o 0 si no se envió ninguna
+func matchTaskVersion(task *models.Task, versions []int) (int, error) {
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	for _, version := range versions {
+		if version == task.Version {
+			return version, nil
+		}
+	}
+	return 0, errors.ErrTaskVersionConflict
+}
+
+// AssignTask fija al responsable principal de la tarea. El usuario debe existir,
+// ser miembro del workspace y poder trabajar en la tarea; null quita a todos los responsables
+func (s *TaskService) AssignTask(ctx context.Context, taskID string, req *models.AssignTaskRequest, actor *models.Actor) (*models.Task, error) {
+	task, err := s.getTaskFor(ctx, taskID, actor, TaskActionAssign)
 	if err != nil {
-		return nil, errors.NewInternalServerError(fmt.Sprintf("error al asignar tarea: %v", err))
+		return nil, err
+	}
+
+	assigneeID := ""
+	if req.AssignedTo != nil {
+		if err := s.checkAssignee(ctx, actor.WorkspaceID, *req.AssignedTo); err != nil {
+			return nil, err
+		}
+		assigneeID = *req.AssignedTo
 	}
 
-	// Obtener tarea actualizada
-	return s.taskRepo.GetByID(ctx, taskID)
+	return s.applyChange(ctx, models.TaskChangeAssigned, taskID, task, actor, func(ctx context.Context) error {
+		if err := s.taskRepo.AssignTask(ctx, actor.WorkspaceID, taskID, assigneeID, actor.UserID); err != nil {
+			return errors.NewInternalServerError(fmt.Sprintf("error al asignar tarea: %v", err))
+		}
+		return nil
+	})
 }
 
-// GetTaskStats obtiene estadísticas de tareas del usuario
-func (s *TaskService) GetTaskStats(ctx context.Context, userID string) (*models.TaskStats, error) {
-	stats, err := s.taskRepo.GetStats(ctx, userID)
+// GetTaskStats obtiene estadísticas de tareas del usuario dentro del workspace
+func (s *TaskService) GetTaskStats(ctx context.Context, workspaceID, userID string) (*models.TaskStats, error) {
+	stats, err := s.taskRepo.GetStats(ctx, workspaceID, models.TaskFilter{UserID: userID})
 	if err != nil {
 		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener estadísticas: %v", err))
 	}
 	return stats, nil
 }
+
+// GetTaskActivity obtiene el historial de cambios de una tarea visible para el actor
+func (s *TaskService) GetTaskActivity(ctx context.Context, taskID string, page, pageSize int, actor *models.Actor) (*models.TaskEventsListResponse, error) {
+	task, err := s.taskRepo.GetByID(ctx, actor.WorkspaceID, taskID, actor.UserID)
+	if err != nil {
+		return nil, errors.ErrTaskNotFound
+	}
+
+	if err := authorizeTask(task, actor, TaskActionView); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = normalizePagination(page, pageSize)
+
+	events, total, err := s.taskRepo.GetEvents(ctx, actor.WorkspaceID, taskID, page, pageSize)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener historial de la tarea: %v", err))
+	}
+
+	return &models.TaskEventsListResponse{
+		Events:     events,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + pageSize - 1) / pageSize,
+	}, nil
+}
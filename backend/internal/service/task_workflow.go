@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// DefaultStatusTransitions transiciones de estado permitidas cuando el workspace no
+// define las suyas. Las tareas completadas o canceladas solo se reabren
+var DefaultStatusTransitions = map[string][]string{
+	models.TaskStatusPending:    {models.TaskStatusInProgress, models.TaskStatusCancelled},
+	models.TaskStatusInProgress: {models.TaskStatusPending, models.TaskStatusCompleted, models.TaskStatusCancelled},
+	models.TaskStatusCompleted:  {models.TaskStatusInProgress},
+	models.TaskStatusCancelled:  {models.TaskStatusPending},
+}
+
+// taskStatuses estados válidos de una tarea
+var taskStatuses = map[string]bool{
+	models.TaskStatusPending:    true,
+	models.TaskStatusInProgress: true,
+	models.TaskStatusCompleted:  true,
+	models.TaskStatusCancelled:  true,
+}
+
+// canTransition indica si la tabla permite pasar de un estado a otro
+func canTransition(transitions map[string][]string, from, to string) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStatusTransition retorna un 409 si la transición no está permitida
+func checkStatusTransition(transitions map[string][]string, from, to string) error {
+	if canTransition(transitions, from, to) {
+		return nil
+	}
+
+	return errors.NewAppError(
+		409,
+		fmt.Sprintf("No se puede cambiar el estado de %s a %s", from, to),
+		fmt.Sprintf("transiciones permitidas desde %s: %v", from, transitions[from]),
+	)
+}
+
+// validateStatusTransitions verifica que una tabla de transiciones personalizada
+// solo use estados conocidos y no contenga transiciones a sí mismo
+func validateStatusTransitions(transitions map[string][]string) error {
+	if len(transitions) == 0 {
+		return errors.NewAppError(422, "Debe definir al menos una transición", "")
+	}
+
+	for from, targets := range transitions {
+		if !taskStatuses[from] {
+			return errors.NewAppError(422, fmt.Sprintf("Estado de origen inválido: %s", from), "")
+		}
+		for _, to := range targets {
+			if !taskStatuses[to] {
+				return errors.NewAppError(422, fmt.Sprintf("Estado de destino inválido: %s", to), "")
+			}
+			if to == from {
+				return errors.NewAppError(422, fmt.Sprintf("Transición inválida de %s a sí mismo", from), "")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/taskflow/backend/internal/domain"
+)
+
+// trashPurgeBatchSize máximo de tareas purgadas por transacción
+const trashPurgeBatchSize = 100
+
+// TrashService elimina definitivamente las tareas que superaron la retención de la papelera
+type TrashService struct {
+	taskRepo  domain.TaskRepository
+	blobStore domain.BlobStore
+	retention time.Duration
+}
+
+// NewTrashService crea una nueva instancia de TrashService
+func NewTrashService(taskRepo domain.TaskRepository, blobStore domain.BlobStore, retention time.Duration) *TrashService {
+	return &TrashService{
+		taskRepo:  taskRepo,
+		blobStore: blobStore,
+		retention: retention,
+	}
+}
+
+// Purge elimina por lotes las tareas que están en la papelera desde antes de
+// now - retención, junto con los archivos de sus adjuntos. Retorna cuántas eliminó
+func (s *TrashService) Purge(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-s.retention)
+
+	purged := 0
+	for {
+		count, keys, err := s.taskRepo.Purge(ctx, cutoff, trashPurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		purged += count
+
+		// Los registros ya no existen: un archivo que no se pudo borrar solo se anota
+		for _, key := range keys {
+			if err := s.blobStore.Delete(ctx, key); err != nil {
+				log.Printf("error al eliminar archivo %s: %v", key, err)
+			}
+		}
+
+		if count < trashPurgeBatchSize || ctx.Err() != nil {
+			return purged, nil
+		}
+	}
+}
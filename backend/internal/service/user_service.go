@@ -12,13 +12,15 @@ import (
 
 // UserService maneja la lógica de negocio de usuarios
 type UserService struct {
-	userRepo domain.UserRepository
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
 }
 
 // NewUserService crea una nueva instancia de UserService
-func NewUserService(userRepo domain.UserRepository) *UserService {
+func NewUserService(userRepo domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
 	}
 }
 
@@ -41,3 +43,36 @@ func (s *UserService) GetAllUsers(ctx context.Context) ([]*models.User, error) {
 	log.Printf("✅ GetAllUsers Service - Success: returned %d users\n", len(users))
 	return users, nil
 }
+
+// UpdateUserRole cambia el rol de un usuario. Un administrador no puede cambiar su propio rol.
+// Se revocan las sesiones del usuario: el rol nuevo rige al volver a iniciar sesión, y el
+// access token vigente conserva el anterior hasta que expira
+func (s *UserService) UpdateUserRole(ctx context.Context, targetID string, req *models.UpdateUserRoleRequest, actorID string) (*models.User, error) {
+	if targetID == actorID {
+		return nil, errors.NewAppError(422, "No puedes cambiar tu propio rol", "")
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, targetID); err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, targetID, req.Role); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al actualizar rol: %v", err))
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, targetID); err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al revocar sesiones: %v", err))
+	}
+
+	log.Printf("✅ UpdateUserRole Service - user=%s role=%s by=%s\n", targetID, req.Role, actorID)
+
+	user, err := s.userRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener usuario: %v", err))
+	}
+
+	return user, nil
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/taskflow/backend/internal/domain"
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+// WorkspaceService maneja la lógica de negocio de workspaces y sus miembros
+type WorkspaceService struct {
+	workspaceRepo domain.WorkspaceRepository
+	userRepo      domain.UserRepository
+}
+
+// NewWorkspaceService crea una nueva instancia de WorkspaceService
+func NewWorkspaceService(workspaceRepo domain.WorkspaceRepository, userRepo domain.UserRepository) *WorkspaceService {
+	return &WorkspaceService{
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// ResolveWorkspace obtiene la membresía del usuario en el workspace solicitado.
+// Si no se indica workspace se usa el más antiguo del usuario, creando uno personal
+// solo si no es miembro de ninguno (no ante cualquier error de la base de datos)
+func (s *WorkspaceService) ResolveWorkspace(ctx context.Context, userID, workspaceID string) (*models.WorkspaceMember, error) {
+	if workspaceID != "" {
+		member, err := s.workspaceRepo.GetMember(ctx, workspaceID, userID)
+		if err != nil {
+			return nil, errors.ErrWorkspaceNotFound
+		}
+		return member, nil
+	}
+
+	member, err := s.workspaceRepo.GetDefaultMembership(ctx, userID)
+	if err == nil {
+		return member, nil
+	}
+	if err != errors.ErrMemberNotFound {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener membresía: %v", err))
+	}
+
+	log.Printf("📝 ResolveWorkspace - Creando workspace personal para userID=%s\n", userID)
+
+	member, err = s.workspaceRepo.EnsureDefaultMembership(ctx, userID, "Personal")
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al crear workspace personal: %v", err))
+	}
+
+	return member, nil
+}
+
+// CreateWorkspace crea un workspace cuyo dueño es el usuario autenticado
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, req *models.CreateWorkspaceRequest, userID string) (*models.Workspace, error) {
+	workspaceID, err := s.workspaceRepo.Create(ctx, req.Name, req.Description, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al crear workspace: %v", err))
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener workspace: %v", err))
+	}
+
+	return workspace, nil
+}
+
+// ListWorkspaces obtiene los workspaces del usuario
+func (s *WorkspaceService) ListWorkspaces(ctx context.Context, userID string) ([]models.Workspace, error) {
+	workspaces, err := s.workspaceRepo.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener workspaces: %v", err))
+	}
+	return workspaces, nil
+}
+
+// GetWorkspace obtiene un workspace del que el usuario es miembro
+func (s *WorkspaceService) GetWorkspace(ctx context.Context, workspaceID, userID string) (*models.Workspace, error) {
+	if _, err := s.requireMember(ctx, workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.ErrWorkspaceNotFound
+	}
+
+	return workspace, nil
+}
+
+// UpdateWorkspace actualiza un workspace (solo dueño o administradores)
+func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, workspaceID string, req *models.UpdateWorkspaceRequest, userID string) (*models.Workspace, error) {
+	member, err := s.requireMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isWorkspaceAdmin(member) {
+		return nil, errors.ErrForbidden
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.ErrWorkspaceNotFound
+	}
+
+	name := workspace.Name
+	if req.Name != nil {
+		name = *req.Name
+	}
+
+	description := workspace.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	if err := s.workspaceRepo.Update(ctx, workspaceID, name, description); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al actualizar workspace: %v", err))
+	}
+
+	return s.workspaceRepo.GetByID(ctx, workspaceID)
+}
+
+// DeleteWorkspace elimina un workspace (solo el dueño)
+func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, workspaceID, userID string) error {
+	member, err := s.requireMember(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role != models.WorkspaceRoleOwner {
+		return errors.ErrForbidden
+	}
+
+	if err := s.workspaceRepo.Delete(ctx, workspaceID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar workspace: %v", err))
+	}
+
+	return nil
+}
+
+// ListMembers obtiene los miembros de un workspace del que el usuario es miembro
+func (s *WorkspaceService) ListMembers(ctx context.Context, workspaceID, userID string) ([]models.WorkspaceMember, error) {
+	if _, err := s.requireMember(ctx, workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.workspaceRepo.ListMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener miembros: %v", err))
+	}
+
+	return members, nil
+}
+
+// InviteMember agrega a un usuario registrado (por email) al workspace
+func (s *WorkspaceService) InviteMember(ctx context.Context, workspaceID string, req *models.InviteMemberRequest, userID string) (*models.WorkspaceMember, error) {
+	member, err := s.requireMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isWorkspaceAdmin(member) {
+		return nil, errors.ErrForbidden
+	}
+
+	invited, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.WorkspaceRoleMember
+	}
+
+	if err := s.workspaceRepo.AddMember(ctx, workspaceID, invited.ID, role); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al agregar miembro: %v", err))
+	}
+
+	log.Printf("✅ InviteMember Service - workspace=%s user=%s role=%s\n", workspaceID, invited.ID, role)
+
+	return s.workspaceRepo.GetMember(ctx, workspaceID, invited.ID)
+}
+
+// RemoveMember elimina a un miembro del workspace. El dueño no puede ser eliminado;
+// los administradores eliminan miembros y cualquier miembro puede salir por sí mismo
+func (s *WorkspaceService) RemoveMember(ctx context.Context, workspaceID, targetUserID, userID string) error {
+	member, err := s.requireMember(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.workspaceRepo.GetMember(ctx, workspaceID, targetUserID)
+	if err != nil {
+		return errors.ErrMemberNotFound
+	}
+
+	if target.Role == models.WorkspaceRoleOwner {
+		return errors.NewAppError(422, "El dueño del workspace no puede ser eliminado", "")
+	}
+
+	isSelf := targetUserID == userID
+	canRemove := isSelf ||
+		member.Role == models.WorkspaceRoleOwner ||
+		(member.Role == models.WorkspaceRoleAdmin && target.Role == models.WorkspaceRoleMember)
+	if !canRemove {
+		return errors.ErrForbidden
+	}
+
+	if err := s.workspaceRepo.RemoveMember(ctx, workspaceID, targetUserID); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return appErr
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("error al eliminar miembro: %v", err))
+	}
+
+	return nil
+}
+
+// GetStatusTransitions obtiene las transiciones de estado vigentes en el workspace
+func (s *WorkspaceService) GetStatusTransitions(ctx context.Context, workspaceID, userID string) (map[string][]string, error) {
+	if _, err := s.requireMember(ctx, workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.workspaceRepo.GetStatusTransitions(ctx, workspaceID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al obtener transiciones de estado: %v", err))
+	}
+
+	if transitions == nil {
+		return DefaultStatusTransitions, nil
+	}
+	return transitions, nil
+}
+
+// UpdateStatusTransitions reemplaza las transiciones de estado del workspace (dueño o administradores)
+func (s *WorkspaceService) UpdateStatusTransitions(ctx context.Context, workspaceID string, req *models.StatusTransitionsRequest, userID string) (map[string][]string, error) {
+	member, err := s.requireMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isWorkspaceAdmin(member) {
+		return nil, errors.ErrForbidden
+	}
+
+	if err := validateStatusTransitions(req.Transitions); err != nil {
+		return nil, err
+	}
+
+	if err := s.workspaceRepo.SetStatusTransitions(ctx, workspaceID, req.Transitions); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al guardar transiciones de estado: %v", err))
+	}
+
+	return req.Transitions, nil
+}
+
+// ResetStatusTransitions restablece las transiciones por defecto (dueño o administradores)
+func (s *WorkspaceService) ResetStatusTransitions(ctx context.Context, workspaceID, userID string) (map[string][]string, error) {
+	member, err := s.requireMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isWorkspaceAdmin(member) {
+		return nil, errors.ErrForbidden
+	}
+
+	if err := s.workspaceRepo.SetStatusTransitions(ctx, workspaceID, nil); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("error al restablecer transiciones de estado: %v", err))
+	}
+
+	return DefaultStatusTransitions, nil
+}
+
+// requireMember retorna la membresía del usuario o ErrWorkspaceNotFound si no pertenece al workspace
+func (s *WorkspaceService) requireMember(ctx context.Context, workspaceID, userID string) (*models.WorkspaceMember, error) {
+	member, err := s.workspaceRepo.GetMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, errors.ErrWorkspaceNotFound
+	}
+	return member, nil
+}
+
+// isWorkspaceAdmin indica si la membresía permite administrar el workspace
+func isWorkspaceAdmin(member *models.WorkspaceMember) bool {
+	return member.Role == models.WorkspaceRoleOwner || member.Role == models.WorkspaceRoleAdmin
+}
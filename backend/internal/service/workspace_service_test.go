@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/taskflow/backend/internal/errors"
+	"github.com/taskflow/backend/internal/models"
+)
+
+func TestResolveWorkspaceCreatesPersonalOnlyWithoutMemberships(t *testing.T) {
+	existing := &models.WorkspaceMember{WorkspaceID: "ws-1", UserID: "user-1", Role: models.WorkspaceRoleMember}
+
+	tests := []struct {
+		name       string
+		defaultErr error
+		wantCreate bool
+		wantErr    bool
+	}{
+		{name: "tiene workspace"},
+		{name: "sin workspaces", defaultErr: errors.ErrMemberNotFound, wantCreate: true},
+		{name: "error de la base de datos", defaultErr: fmt.Errorf("conexión cerrada"), wantErr: true},
+		{name: "contexto cancelado", defaultErr: context.Canceled, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := 0
+			repo := &MockWorkspaceRepository{
+				GetDefaultMembershipFunc: func(ctx context.Context, userID string) (*models.WorkspaceMember, error) {
+					if tt.defaultErr != nil {
+						return nil, tt.defaultErr
+					}
+					return existing, nil
+				},
+				EnsureDefaultMembershipFunc: func(ctx context.Context, userID, name string) (*models.WorkspaceMember, error) {
+					created++
+					return &models.WorkspaceMember{WorkspaceID: "personal", UserID: userID, Role: models.WorkspaceRoleOwner}, nil
+				},
+				CreateFunc: func(ctx context.Context, name, description, ownerID string) (string, error) {
+					t.Fatal("no debe crear el workspace personal con Create")
+					return "", nil
+				},
+			}
+
+			member, err := NewWorkspaceService(repo, &MockUserRepository{}).ResolveWorkspace(context.Background(), "user-1", "")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("se esperaba error, se obtuvo %+v", member)
+				}
+			} else if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+
+			if (created == 1) != tt.wantCreate || created > 1 {
+				t.Errorf("workspaces personales creados = %d, se esperaba creación=%v", created, tt.wantCreate)
+			}
+			if tt.wantCreate && member.WorkspaceID != "personal" {
+				t.Errorf("workspace = %s, se esperaba el personal", member.WorkspaceID)
+			}
+		})
+	}
+}
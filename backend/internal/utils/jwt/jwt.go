@@ -7,10 +7,18 @@ import (
 	jwtlib "github.com/golang-jwt/jwt/v5"
 )
 
+// Tipos de token soportados en el claim token_use
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 // Claims estructura para los claims del JWT
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role,omitempty"`
+	TokenType string `json:"token_use"`
 	jwtlib.RegisteredClaims
 }
 
@@ -30,13 +38,15 @@ func NewManager(secret string, expirationTime, refreshExpiration int64) *Manager
 	}
 }
 
-// GenerateToken genera un nuevo access token
-func (m *Manager) GenerateToken(userID, email string) (string, error) {
+// GenerateToken genera un nuevo access token que incluye el rol del usuario
+func (m *Manager) GenerateToken(userID, email, role string) (string, error) {
 	expirationTime := time.Now().Add(time.Duration(m.expirationTime) * time.Second)
 
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		TokenType: TokenTypeAccess,
 		RegisteredClaims: jwtlib.RegisteredClaims{
 			ExpiresAt: jwtlib.NewNumericDate(expirationTime),
 			IssuedAt:  jwtlib.NewNumericDate(time.Now()),
@@ -53,14 +63,21 @@ func (m *Manager) GenerateToken(userID, email string) (string, error) {
 	return tokenString, nil
 }
 
-// GenerateRefreshToken genera un nuevo refresh token
-func (m *Manager) GenerateRefreshToken(userID, email string) (string, error) {
+// RefreshTokenTTL retorna la duración de vida de un refresh token
+func (m *Manager) RefreshTokenTTL() time.Duration {
+	return time.Duration(m.refreshExpiration) * time.Second
+}
+
+// GenerateRefreshToken genera un nuevo refresh token identificado por tokenID (claim jti)
+func (m *Manager) GenerateRefreshToken(userID, email, tokenID string) (string, error) {
 	expirationTime := time.Now().Add(time.Duration(m.refreshExpiration) * time.Second)
 
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:    userID,
+		Email:     email,
+		TokenType: TokenTypeRefresh,
 		RegisteredClaims: jwtlib.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwtlib.NewNumericDate(expirationTime),
 			IssuedAt:  jwtlib.NewNumericDate(time.Now()),
 			NotBefore: jwtlib.NewNumericDate(time.Now()),
@@ -76,13 +93,23 @@ func (m *Manager) GenerateRefreshToken(userID, email string) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken valida un token y retorna los claims
-func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
+// ValidateAccessToken valida un access token y retorna los claims
+func (m *Manager) ValidateAccessToken(tokenString string) (*Claims, error) {
+	return m.validateToken(tokenString, TokenTypeAccess)
+}
+
+// ValidateRefreshToken valida un refresh token y retorna los claims
+func (m *Manager) ValidateRefreshToken(tokenString string) (*Claims, error) {
+	return m.validateToken(tokenString, TokenTypeRefresh)
+}
+
+// validateToken valida un token, verifica que sea del tipo esperado y retorna los claims
+func (m *Manager) validateToken(tokenString, expectedType string) (*Claims, error) {
 	claims := &Claims{}
 
 	token, err := jwtlib.ParseWithClaims(tokenString, claims, func(token *jwtlib.Token) (interface{}, error) {
 		return []byte(m.secret), nil
-	})
+	}, jwtlib.WithValidMethods([]string{jwtlib.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, fmt.Errorf("error al parsear token: %w", err)
@@ -92,5 +119,9 @@ func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, fmt.Errorf("token inválido")
 	}
 
+	if claims.TokenType != expectedType {
+		return nil, fmt.Errorf("tipo de token inválido: se esperaba %s", expectedType)
+	}
+
 	return claims, nil
 }
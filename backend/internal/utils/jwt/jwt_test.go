@@ -0,0 +1,72 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "secreto-de-prueba"
+
+func TestValidateTokenType(t *testing.T) {
+	m := NewManager(testSecret, 3600, 604800)
+
+	access, err := m.GenerateToken("user-1", "ana@example.com", "member")
+	if err != nil {
+		t.Fatalf("error al generar access token: %v", err)
+	}
+	refresh, err := m.GenerateRefreshToken("user-1", "ana@example.com", "token-1")
+	if err != nil {
+		t.Fatalf("error al generar refresh token: %v", err)
+	}
+
+	if _, err := m.ValidateAccessToken(access); err != nil {
+		t.Errorf("ValidateAccessToken rechazó un access token: %v", err)
+	}
+	if _, err := m.ValidateRefreshToken(refresh); err != nil {
+		t.Errorf("ValidateRefreshToken rechazó un refresh token: %v", err)
+	}
+	if _, err := m.ValidateRefreshToken(access); err == nil {
+		t.Error("ValidateRefreshToken aceptó un access token")
+	}
+	if _, err := m.ValidateAccessToken(refresh); err == nil {
+		t.Error("ValidateAccessToken aceptó un refresh token")
+	}
+}
+
+func TestValidateTokenRejectsOtherAlgorithms(t *testing.T) {
+	m := NewManager(testSecret, 3600, 604800)
+
+	claims := &Claims{
+		UserID:    "user-1",
+		Email:     "ana@example.com",
+		Role:      "admin",
+		TokenType: TokenTypeAccess,
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ExpiresAt: jwtlib.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		method jwtlib.SigningMethod
+		key    interface{}
+	}{
+		{name: "HS512", method: jwtlib.SigningMethodHS512, key: []byte(testSecret)},
+		{name: "none", method: jwtlib.SigningMethodNone, key: jwtlib.UnsafeAllowNoneSignatureType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwtlib.NewWithClaims(tt.method, claims).SignedString(tt.key)
+			if err != nil {
+				t.Fatalf("error al firmar token: %v", err)
+			}
+
+			if _, err := m.ValidateAccessToken(token); err == nil {
+				t.Errorf("se aceptó un token firmado con %s", tt.name)
+			}
+		})
+	}
+}
@@ -4,10 +4,16 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
+// MaxRequestBodySize tamaño máximo del body de un request (10MB). También limita
+// el tamaño de los archivos adjuntos
+const MaxRequestBodySize = 10 * 1024 * 1024
+
 // ValidateUUID valida que una cadena sea un UUID válido
 func ValidateUUID(id string) error {
 	if id == "" {
@@ -50,6 +56,72 @@ func ValidatePriority(priority string) error {
 	return nil
 }
 
+// ValidateTaskSort valida que el campo de orden sea uno de los permitidos
+func ValidateTaskSort(field string) error {
+	validFields := map[string]bool{
+		"created_at": true,
+		"due_date":   true,
+		"priority":   true,
+		"updated_at": true,
+		"title":      true,
+	}
+
+	if !validFields[field] {
+		return fmt.Errorf("orden inválido: debe ser uno de created_at, due_date, priority, updated_at, title")
+	}
+	return nil
+}
+
+// ParseDate interpreta una fecha en formato YYYY-MM-DD o RFC3339. endOfDay indica
+// si una fecha sin hora representa el final del día (útil para límites superiores)
+func ParseDate(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fecha inválida: use YYYY-MM-DD o RFC3339")
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// ParseDueDate interpreta la fecha límite de una tarea: RFC3339, fecha y hora sin zona
+// horaria (con T o con espacio) o solo fecha
+func ParseDueDate(value string) (time.Time, error) {
+	formats := []string{
+		time.RFC3339,          // "2006-01-02T15:04:05Z07:00"
+		"2006-01-02T15:04:05", // Sin timezone
+		"2006-01-02 15:04:05", // Con espacio
+		"2006-01-02",          // Solo fecha
+	}
+
+	for _, format := range formats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("formato de fecha inválido: %s", value)
+}
+
+// ValidateRole valida que el rol sea uno de los permitidos
+func ValidateRole(role string) error {
+	validRoles := map[string]bool{
+		"admin":  true,
+		"member": true,
+		"viewer": true,
+	}
+
+	if !validRoles[role] {
+		return fmt.Errorf("rol inválido: debe ser uno de admin, member, viewer")
+	}
+	return nil
+}
+
 // ValidateEmail valida que sea un email válido
 func ValidateEmail(email string) error {
 	if email == "" {
@@ -143,16 +215,15 @@ func ValidatePaginationParams(page, pageSize int) (int, int, error) {
 	return page, pageSize, nil
 }
 
-// ValidateTaskTitle valida el título de una tarea
+// ValidateTaskTitle valida el título de una tarea. Es la única validación del título
+// al crear, reemplazar (PUT) o actualizar parcialmente (PATCH) una tarea
 func ValidateTaskTitle(title string) error {
-	title = strings.TrimSpace(title)
-
-	if len(title) == 0 {
+	if strings.TrimSpace(title) == "" {
 		return fmt.Errorf("título no puede estar vacío")
 	}
 
-	if len(title) > 200 {
-		return fmt.Errorf("título debe tener máximo 200 caracteres")
+	if utf8.RuneCountInString(title) > 100 {
+		return fmt.Errorf("título debe tener máximo 100 caracteres")
 	}
 
 	return nil
@@ -160,8 +231,8 @@ func ValidateTaskTitle(title string) error {
 
 // ValidateTaskDescription valida la descripción de una tarea
 func ValidateTaskDescription(description string) error {
-	if len(description) > 2000 {
-		return fmt.Errorf("descripción debe tener máximo 2000 caracteres")
+	if utf8.RuneCountInString(description) > 500 {
+		return fmt.Errorf("descripción debe tener máximo 500 caracteres")
 	}
 
 	return nil
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/taskflow/backend/internal/service"
+)
+
+// EventWorker elimina periódicamente los eventos en tiempo real que superaron la retención
+type EventWorker struct {
+	eventHub *service.EventHub
+	interval time.Duration
+}
+
+// NewEventWorker crea un purgador que se ejecuta cada interval
+func NewEventWorker(eventHub *service.EventHub, interval time.Duration) *EventWorker {
+	return &EventWorker{
+		eventHub: eventHub,
+		interval: interval,
+	}
+}
+
+// Start ejecuta el purgador hasta que se cancele el contexto
+func (w *EventWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run ejecuta una pasada del purgador
+func (w *EventWorker) run(ctx context.Context) {
+	deleted, err := w.eventHub.Purge(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("🔴 ERROR en purga de eventos de tareas: %v\n", err)
+	}
+
+	if deleted > 0 {
+		log.Printf("📡 Eventos de tareas vencidos eliminados: %d\n", deleted)
+	}
+}
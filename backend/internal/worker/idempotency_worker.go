@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/taskflow/backend/internal/domain"
+)
+
+// IdempotencyWorker elimina periódicamente las Idempotency-Key vencidas
+type IdempotencyWorker struct {
+	store    domain.IdempotencyStore
+	interval time.Duration
+}
+
+// NewIdempotencyWorker crea un purgador que se ejecuta cada interval
+func NewIdempotencyWorker(store domain.IdempotencyStore, interval time.Duration) *IdempotencyWorker {
+	return &IdempotencyWorker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Start ejecuta el purgador hasta que se cancele el contexto
+func (w *IdempotencyWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run ejecuta una pasada del purgador
+func (w *IdempotencyWorker) run(ctx context.Context) {
+	deleted, err := w.store.DeleteExpired(ctx)
+	if err != nil {
+		log.Printf("🔴 ERROR en purga de idempotency keys: %v\n", err)
+	}
+
+	if deleted > 0 {
+		log.Printf("🔑 Idempotency keys vencidas eliminadas: %d\n", deleted)
+	}
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/taskflow/backend/internal/service"
+)
+
+// RecurrenceWorker genera periódicamente las ocurrencias de las tareas repetitivas
+type RecurrenceWorker struct {
+	recurrenceService *service.RecurrenceService
+	interval          time.Duration
+}
+
+// NewRecurrenceWorker crea un generador que se ejecuta cada interval
+func NewRecurrenceWorker(recurrenceService *service.RecurrenceService, interval time.Duration) *RecurrenceWorker {
+	return &RecurrenceWorker{
+		recurrenceService: recurrenceService,
+		interval:          interval,
+	}
+}
+
+// Start ejecuta el generador hasta que se cancele el contexto. La primera pasada
+// es inmediata para recuperar las ocurrencias pendientes tras un reinicio
+func (w *RecurrenceWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run ejecuta una pasada del generador
+func (w *RecurrenceWorker) run(ctx context.Context) {
+	created, err := w.recurrenceService.GenerateDue(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("🔴 ERROR en generador de tareas repetitivas: %v\n", err)
+		return
+	}
+
+	if created > 0 {
+		log.Printf("🔁 Tareas repetitivas generadas: %d\n", created)
+	}
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/taskflow/backend/internal/service"
+)
+
+// TrashWorker purga periódicamente las tareas que superaron la retención de la papelera
+type TrashWorker struct {
+	trashService *service.TrashService
+	interval     time.Duration
+}
+
+// NewTrashWorker crea un purgador que se ejecuta cada interval
+func NewTrashWorker(trashService *service.TrashService, interval time.Duration) *TrashWorker {
+	return &TrashWorker{
+		trashService: trashService,
+		interval:     interval,
+	}
+}
+
+// Start ejecuta el purgador hasta que se cancele el contexto
+func (w *TrashWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run ejecuta una pasada del purgador
+func (w *TrashWorker) run(ctx context.Context) {
+	purged, err := w.trashService.Purge(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("🔴 ERROR en purga de la papelera: %v\n", err)
+	}
+
+	if purged > 0 {
+		log.Printf("🗑️ Tareas eliminadas definitivamente: %d\n", purged)
+	}
+}
@@ -1,21 +1,35 @@
 package main
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/taskflow/backend/docs"
 	"github.com/taskflow/backend/internal/config"
+	"github.com/taskflow/backend/internal/domain"
 	"github.com/taskflow/backend/internal/handler"
 	"github.com/taskflow/backend/internal/infrastructure/database"
 	"github.com/taskflow/backend/internal/infrastructure/response"
 	"github.com/taskflow/backend/internal/infrastructure/router"
+	"github.com/taskflow/backend/internal/infrastructure/storage"
 	"github.com/taskflow/backend/internal/repository/postgres"
 	"github.com/taskflow/backend/internal/service"
 	"github.com/taskflow/backend/internal/utils/jwt"
+	"github.com/taskflow/backend/internal/worker"
 )
 
+// shutdownTimeout tiempo máximo para terminar los requests en curso al recibir SIGTERM
+const shutdownTimeout = 15 * time.Second
+
 // @title TaskFlow API
 // @version 1.0
 // @description API REST para gestión de tareas colaborativas
@@ -67,22 +81,105 @@ func main() {
 	// Crear repositorios
 	userRepo := postgres.NewUserRepository(db)
 	taskRepo := postgres.NewTaskRepository(db)
+	refreshTokenRepo := postgres.NewRefreshTokenRepository(db)
+	workspaceRepo := postgres.NewWorkspaceRepository(db)
+	projectRepo := postgres.NewProjectRepository(db)
+	commentRepo := postgres.NewCommentRepository(db)
+	checklistRepo := postgres.NewChecklistRepository(db)
+	dependencyRepo := postgres.NewDependencyRepository(db)
+	recurrenceRepo := postgres.NewRecurrenceRepository(db)
+	labelRepo := postgres.NewLabelRepository(db)
+	attachmentRepo := postgres.NewAttachmentRepository(db)
+	transactor := postgres.NewTransactor(db)
+	idempotencyStore := postgres.NewIdempotencyStore(db)
+	taskChangeRepo := postgres.NewTaskChangeRepository(db)
+
+	// Crear almacenamiento de archivos adjuntos
+	var blobStore domain.BlobStore
+	switch cfg.StorageDriver {
+	case "s3":
+		blobStore, err = storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	case "local":
+		blobStore, err = storage.NewLocalStore(cfg.StoragePath)
+	default:
+		err = fmt.Errorf("driver desconocido: %s", cfg.StorageDriver)
+	}
+	if err != nil {
+		log.Fatalf("Error configurando almacenamiento: %v", err)
+	}
 
 	// Crear servicios
-	authService := service.NewAuthService(userRepo, jwtManager)
-	taskService := service.NewTaskService(taskRepo)
-	userService := service.NewUserService(userRepo)
+	eventHub := service.NewEventHub(taskChangeRepo, workspaceRepo, userRepo, time.Duration(cfg.EventRetention)*time.Second)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, jwtManager)
+	taskService := service.NewTaskService(taskRepo, projectRepo, workspaceRepo, dependencyRepo, userRepo, transactor, eventHub)
+	userService := service.NewUserService(userRepo, refreshTokenRepo)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, userRepo)
+	projectService := service.NewProjectService(projectRepo, taskRepo)
+	commentService := service.NewCommentService(commentRepo, taskRepo, userRepo, workspaceRepo)
+	checklistService := service.NewChecklistService(checklistRepo, taskRepo, transactor, eventHub)
+	dependencyService := service.NewDependencyService(dependencyRepo, taskRepo, transactor)
+	recurrenceService := service.NewRecurrenceService(recurrenceRepo, taskRepo, transactor, eventHub)
+	labelService := service.NewLabelService(labelRepo, taskRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, blobStore, cfg.AttachmentMaxSize)
+	bulkTaskService := service.NewBulkTaskService(taskService, labelService, transactor, cfg.BulkMaxTasks)
+	trashService := service.NewTrashService(taskRepo, blobStore, time.Duration(cfg.TrashRetentionDays)*24*time.Hour)
 
 	// Crear handlers con inyección de ResponseWriter
 	authHandler := handler.NewAuthHandler(authService, rw)
 	taskHandler := handler.NewTaskHandler(taskService, rw)
 	userHandler := handler.NewUserHandler(userService, rw)
+	workspaceHandler := handler.NewWorkspaceHandler(workspaceService, rw)
+	projectHandler := handler.NewProjectHandler(projectService, rw)
+	commentHandler := handler.NewCommentHandler(commentService, rw)
+	checklistHandler := handler.NewChecklistHandler(checklistService, rw)
+	dependencyHandler := handler.NewDependencyHandler(dependencyService, rw)
+	recurrenceHandler := handler.NewRecurrenceHandler(recurrenceService, rw)
+	labelHandler := handler.NewLabelHandler(labelService, rw)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, rw)
+	bulkTaskHandler := handler.NewBulkTaskHandler(bulkTaskService, rw)
+	eventHandler := handler.NewEventHandler(eventHub, cfg.EventAllowedOrigins, rw)
+
+	// Iniciar generador de tareas repetitivas
+	recurrenceWorker := worker.NewRecurrenceWorker(recurrenceService, time.Duration(cfg.RecurrenceInterval)*time.Second)
+	trashWorker := worker.NewTrashWorker(trashService, time.Duration(cfg.TrashPurgeInterval)*time.Second)
+	idempotencyWorker := worker.NewIdempotencyWorker(idempotencyStore, time.Duration(cfg.IdempotencyPurgeInterval)*time.Second)
+	eventWorker := worker.NewEventWorker(eventHub, time.Duration(cfg.EventPurgeInterval)*time.Second)
+	taskChangeListener := postgres.NewTaskChangeListener(cfg.GetDSN())
+
+	// SIGTERM o Ctrl+C cancelan ctx: se detienen los workers y la recepción de eventos
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var background sync.WaitGroup
+	for _, run := range []func(ctx context.Context){
+		// Generador de tareas repetitivas
+		recurrenceWorker.Start,
+		// Purga de la papelera
+		trashWorker.Start,
+		// Purga de idempotency keys
+		idempotencyWorker.Start,
+		// Recepción de eventos de tareas de todas las instancias (LISTEN/NOTIFY) y purga de los vencidos
+		func(ctx context.Context) { eventHub.Run(ctx, taskChangeListener) },
+		eventWorker.Start,
+	} {
+		background.Add(1)
+		go func(run func(ctx context.Context)) {
+			defer background.Done()
+			run(ctx)
+		}(run)
+	}
 
 	// Crear engine de Gin
 	engine := gin.Default()
 
 	// Setup de rutas
-	router.Setup(engine, authHandler, taskHandler, userHandler, jwtManager)
+	router.Setup(engine, authHandler, taskHandler, userHandler, workspaceHandler, projectHandler, commentHandler, checklistHandler, dependencyHandler, recurrenceHandler, labelHandler, attachmentHandler, bulkTaskHandler, eventHandler, workspaceService, idempotencyStore, time.Duration(cfg.IdempotencyTTL)*time.Second, jwtManager)
 
 	// Iniciar servidor
 	addr := fmt.Sprintf(":%d", cfg.ServerPort)
@@ -90,7 +187,26 @@ func main() {
 	fmt.Printf("\n🚀 API iniciada en http://localhost%s\n", addr)
 	fmt.Printf("📚 Documentación Swagger: http://localhost%s/swagger/index.html\n\n", addr)
 
-	if err := engine.Run(addr); err != nil {
-		log.Fatalf("Error iniciando servidor: %v", err)
+	server := &http.Server{Addr: addr, Handler: engine}
+	// Los streams de eventos no terminan solos: se cierran al empezar el apagado para
+	// que Shutdown solo espere a los requests comunes
+	server.RegisterOnShutdown(eventHub.Close)
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Error iniciando servidor: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	fmt.Println("\n🛑 Deteniendo API...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("🔴 ERROR al detener servidor: %v\n", err)
 	}
+
+	// Esperar a que los workers terminen la pasada en curso antes de cerrar la base de datos
+	background.Wait()
 }
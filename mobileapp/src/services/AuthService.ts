@@ -23,6 +23,14 @@ export class AuthService {
   }
 
   async logout() {
+    try {
+      const { refresh_token } = await Session.getSession();
+      if (refresh_token) {
+        await api.post('/auth/logout', { refresh_token });
+      }
+    } catch (error: any) {
+      // La sesión local se limpia aunque el servidor no responda
+    }
     await Session.clearSession();
     return true;
   }